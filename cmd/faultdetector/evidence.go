@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+)
+
+const (
+	verifyEvidenceCommand = "verify-evidence"
+
+	evidenceFormatText = "text"
+)
+
+var errEvidenceVerificationFailed = errors.New("evidence does not match or could not be verified")
+
+// verifyEvidenceOptions are the command line options of the `verify-evidence` subcommand.
+type verifyEvidenceOptions struct {
+	inputFilepath  string
+	format         string
+	outputFilepath string
+}
+
+// parseVerifyEvidenceOptions parses and validates the command line options of the `verify-evidence` subcommand.
+func parseVerifyEvidenceOptions(args []string) (*verifyEvidenceOptions, error) {
+	opts := &verifyEvidenceOptions{}
+	flags := flag.NewFlagSet(verifyEvidenceCommand, flag.ContinueOnError)
+	flags.StringVar(&opts.inputFilepath, "input", "", "Path to the evidence bundle, either JSON or RLP encoded")
+	flags.StringVar(&opts.format, "format", evidenceFormatText, "Format of the result, either text or json")
+	flags.StringVar(&opts.outputFilepath, "output", "", "Path to the result file, the result is printed to stdout when not given")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if len(opts.inputFilepath) == 0 {
+		return nil, fmt.Errorf("--input is required")
+	}
+	if opts.format != evidenceFormatText && opts.format != reportFormatJSON {
+		return nil, fmt.Errorf("--format expected one of [%s %s], received: '%s'", evidenceFormatText, reportFormatJSON, opts.format)
+	}
+
+	return opts, nil
+}
+
+// runVerifyEvidence verifies the evidence bundle given by the command line arguments offline and writes the result.
+// It returns [errEvidenceVerificationFailed] when the output root does not match or could not be verified.
+func runVerifyEvidence(args []string, stdout io.Writer) error {
+	opts, err := parseVerifyEvidenceOptions(args)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(opts.inputFilepath)
+	if err != nil {
+		return err
+	}
+	bundle, err := faultdetector.DecodeEvidenceBundle(content)
+	if err != nil {
+		return err
+	}
+
+	verification := faultdetector.VerifyEvidence(bundle)
+
+	w := stdout
+	if len(opts.outputFilepath) > 0 {
+		file, err := os.Create(opts.outputFilepath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := writeEvidenceVerification(w, opts.format, verification); err != nil {
+		return err
+	}
+
+	if verification.Verdict != faultdetector.VerdictOk {
+		return errEvidenceVerificationFailed
+	}
+
+	return nil
+}
+
+// writeEvidenceVerification writes the verdict along with every intermediate value in the given format.
+func writeEvidenceVerification(w io.Writer, format string, verification *faultdetector.EvidenceVerification) error {
+	if format == reportFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(verification)
+	}
+
+	proofStatus := "invalid"
+	if verification.ProofValid {
+		proofStatus = "valid"
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Output index:\t%d\n", verification.OutputIndex)
+	fmt.Fprintf(writer, "L2 block number:\t%d\n", verification.L2BlockNumber)
+	fmt.Fprintf(writer, "L1 timestamp:\t%d\n", verification.L1Timestamp)
+	fmt.Fprintf(writer, "L2 block hash:\t%s\n", verification.BlockHash)
+	fmt.Fprintf(writer, "State root:\t%s\n", verification.StateRoot)
+	fmt.Fprintf(writer, "Message passer storage root:\t%s\n", verification.MessagePasserStorageRoot)
+	fmt.Fprintf(writer, "Account proof:\t%s\n", proofStatus)
+	fmt.Fprintf(writer, "Expected output root:\t%s\n", verification.ExpectedOutputRoot)
+	fmt.Fprintf(writer, "Calculated output root:\t%s\n", verification.CalculatedOutputRoot)
+	fmt.Fprintf(writer, "Verdict:\t%s\n", verification.Verdict)
+	if len(verification.Error) > 0 {
+		fmt.Fprintf(writer, "Error:\t%s\n", verification.Error)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/chain/chaintest"
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVerifyEvidenceOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr bool
+	}{
+		{
+			name: "should parse the evidence bundle path",
+			args: []string{"--input", "evidence.json"},
+		},
+		{
+			name: "should parse the format and the output file",
+			args: []string{"--input", "evidence.rlp", "--format", "json", "--output", "result.json"},
+		},
+		{
+			name:        "should return error when the evidence bundle path is missing",
+			args:        []string{"--format", "json"},
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the format is unknown",
+			args:        []string{"--input", "evidence.json", "--format", "csv"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseVerifyEvidenceOptions(test.args)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// newFakeEvidenceBundle returns the evidence bundle of the output of the given L2 block, with the block header and the account proof served by the fake L2 node.
+func newFakeEvidenceBundle(t *testing.T, l2 *chaintest.L2, l2BlockNumber uint64, outputRoot common.Hash) *faultdetector.EvidenceBundle {
+	ctx := context.Background()
+	logger, err := log.NewDefaultProductionLogger()
+	require.NoError(t, err)
+	client, err := chain.GetAPIClient(ctx, l2.URL(), logger)
+	require.NoError(t, err)
+
+	header, err := client.GetBlockHeaderByNumber(ctx, new(big.Int).SetUint64(l2BlockNumber))
+	require.NoError(t, err)
+	proof, err := client.GetProof(ctx, new(big.Int).SetUint64(l2BlockNumber), common.HexToAddress(chain.L2BedrockMessagePasserAddress))
+	require.NoError(t, err)
+
+	return &faultdetector.EvidenceBundle{
+		ChainName: fakeChainName,
+		L2ChainID: fakeL2ChainID,
+		Output: faultdetector.EvidenceOutput{
+			OutputIndex:   1,
+			OutputRoot:    outputRoot,
+			L2BlockNumber: l2BlockNumber,
+			L1Timestamp:   1500,
+		},
+		Header: header,
+		Proof:  proof,
+	}
+}
+
+func TestRunVerifyEvidence(t *testing.T) {
+	const l2BlockNumber = 20
+	l2 := chaintest.NewL2(chaintest.L2Config{ChainID: fakeL2ChainID, GenesisTime: 1000, BlockTime: fakeL2BlockTime})
+	defer l2.Close()
+	l2.Mine(l2BlockNumber)
+
+	tests := []struct {
+		name            string
+		outputRoot      common.Hash
+		encode          func(bundle *faultdetector.EvidenceBundle) ([]byte, error)
+		format          string
+		expectedErr     error
+		expectedVerdict string
+	}{
+		{
+			name:       "should print ok for the JSON evidence bundle of a valid output",
+			outputRoot: l2.OutputRoot(l2BlockNumber),
+			encode: func(bundle *faultdetector.EvidenceBundle) ([]byte, error) {
+				return json.Marshal(bundle)
+			},
+			format:          evidenceFormatText,
+			expectedVerdict: faultdetector.VerdictOk,
+		},
+		{
+			name:       "should print mismatch for the RLP evidence bundle of a faulty output",
+			outputRoot: common.HexToHash("0x01"),
+			encode: func(bundle *faultdetector.EvidenceBundle) ([]byte, error) {
+				return rlp.EncodeToBytes(bundle)
+			},
+			format:          reportFormatJSON,
+			expectedErr:     errEvidenceVerificationFailed,
+			expectedVerdict: faultdetector.VerdictMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, err := test.encode(newFakeEvidenceBundle(t, l2, l2BlockNumber, test.outputRoot))
+			require.NoError(t, err)
+			inputFilepath := filepath.Join(t.TempDir(), "evidence")
+			require.NoError(t, os.WriteFile(inputFilepath, content, 0o600))
+
+			var stdout bytes.Buffer
+			err = runVerifyEvidence([]string{"--input", inputFilepath, "--format", test.format}, &stdout)
+			require.Equal(t, test.expectedErr, err)
+
+			if test.format == reportFormatJSON {
+				var verification faultdetector.EvidenceVerification
+				require.NoError(t, json.Unmarshal(stdout.Bytes(), &verification))
+				require.Equal(t, test.expectedVerdict, verification.Verdict)
+				require.Equal(t, l2.OutputRoot(l2BlockNumber).Hex(), verification.CalculatedOutputRoot)
+				return
+			}
+			require.Regexp(t, `Verdict:\s+`+test.expectedVerdict, stdout.String())
+			require.Regexp(t, `Account proof:\s+valid`, stdout.String())
+			require.Contains(t, stdout.String(), test.outputRoot.Hex())
+		})
+	}
+}
@@ -27,14 +27,15 @@ import (
 
 // App encapsulates start and stop logic for the whole application.
 type App struct {
-	ctx           context.Context
-	logger        log.Logger
-	errChan       chan error
-	config        *config.Config
-	wg            *sync.WaitGroup
-	apiServer     *api.HTTPServer
-	faultDetector *faultdetector.FaultDetector
-	notification  *notification.Notification
+	ctx            context.Context
+	logger         log.Logger
+	errChan        chan error
+	config         *config.Config
+	wg             *sync.WaitGroup
+	apiServer      *api.HTTPServer
+	faultDetectors []*faultdetector.FaultDetector
+	notification   *notification.Notification
+	stopOnce       sync.Once
 }
 
 // NewApp returns [App] with all the initialized services and variables.
@@ -71,20 +72,24 @@ func NewApp(ctx context.Context, logger log.Logger) (*App, error) {
 		}
 	}
 
-	// Start Fault Detector
-	faultDetector, err := faultdetector.NewFaultDetector(
-		ctx,
-		logger,
-		errorChan,
-		&wg,
-		config.FaultDetectorConfig,
-		reg,
-		notificationChannel,
-	)
+	// Start Fault Detector for every configured chain
+	faultDetectors := make([]*faultdetector.FaultDetector, 0, len(config.FaultDetectorConfigs))
+	for _, faultDetectorConfig := range config.FaultDetectorConfigs {
+		faultDetector, err := faultdetector.NewFaultDetector(
+			ctx,
+			logger,
+			errorChan,
+			&wg,
+			faultDetectorConfig,
+			reg,
+			notificationChannel,
+		)
 
-	if err != nil {
-		logger.Errorf("Failed to create fault detector service.")
-		return nil, err
+		if err != nil {
+			logger.Errorf("Failed to create fault detector service for chain %s.", faultDetectorConfig.Name)
+			return nil, err
+		}
+		faultDetectors = append(faultDetectors, faultDetector)
 	}
 
 	// Start API Server
@@ -94,14 +99,14 @@ func NewApp(ctx context.Context, logger log.Logger) (*App, error) {
 	apiServer.RegisterHandler("GET", "/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg, ProcessStartTime: time.Now()}))
 
 	return &App{
-		ctx:           ctx,
-		logger:        logger,
-		errChan:       errorChan,
-		config:        config,
-		wg:            &wg,
-		apiServer:     apiServer,
-		faultDetector: faultDetector,
-		notification:  notificationChannel,
+		ctx:            ctx,
+		logger:         logger,
+		errChan:        errorChan,
+		config:         config,
+		wg:             &wg,
+		apiServer:      apiServer,
+		faultDetectors: faultDetectors,
+		notification:   notificationChannel,
 	}, nil
 }
 
@@ -111,10 +116,12 @@ func (app *App) Start() {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
 
-	app.wg.Add(1)
-	go app.faultDetector.Start()
+	for _, faultDetector := range app.faultDetectors {
+		app.wg.Add(1)
+		go faultDetector.Start()
+	}
 
-	app.apiServer.RegisterHandlersForVersions(app.faultDetector, app.config.Api.RegisterVersions, app.config.Api.BasePath)
+	app.apiServer.RegisterHandlersForVersions(app.faultDetectors, app.config.Api.RegisterVersions, app.config.Api.BasePath)
 	app.wg.Add(1)
 	go app.apiServer.Start()
 
@@ -145,12 +152,17 @@ func (app *App) Start() {
 	}
 }
 
+// stop stops the services, only once even though the application is stopped again when all the services have returned.
 func (app *App) stop() {
-	app.faultDetector.Stop()
-	err := app.apiServer.Stop()
-	if err != nil {
-		app.logger.Error("Server shutdown not successful: %v", err)
-	}
+	app.stopOnce.Do(func() {
+		for _, faultDetector := range app.faultDetectors {
+			faultDetector.Stop()
+		}
+		err := app.apiServer.Stop()
+		if err != nil {
+			app.logger.Error("Server shutdown not successful: %v", err)
+		}
+	})
 }
 
 func main() {
@@ -163,6 +175,26 @@ func main() {
 		return
 	}
 
+	// Verify a range of outputs and exit, instead of running the fault detector service
+	if len(os.Args) > 1 && os.Args[1] == verifyCommand {
+		if err := runVerify(ctx, logger, os.Args[2:]); err != nil {
+			logger.Errorf("Failed to verify outputs, %v", err)
+			cancel()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Verify an evidence bundle offline and exit, without any RPC access
+	if len(os.Args) > 1 && os.Args[1] == verifyEvidenceCommand {
+		if err := runVerifyEvidence(os.Args[2:], os.Stdout); err != nil {
+			logger.Errorf("Failed to verify evidence, %v", err)
+			cancel()
+			os.Exit(1)
+		}
+		return
+	}
+
 	app, err := NewApp(ctx, logger)
 	if err != nil {
 		logger.Errorf("Failed to create app, %v", err)
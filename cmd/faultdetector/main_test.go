@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	crand "crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/LiskHQ/op-fault-detector/pkg/api"
 	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/chain/chaintest"
 	"github.com/LiskHQ/op-fault-detector/pkg/config"
 	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
 	"github.com/LiskHQ/op-fault-detector/pkg/log"
@@ -29,6 +32,7 @@ import (
 	slackClient "github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -100,7 +104,7 @@ func prepareNotification(t *testing.T, ctx context.Context, logger log.Logger, c
 func prepareFaultDetector(t *testing.T, ctx context.Context, logger log.Logger, testNotificationService *notification.Notification, wg *sync.WaitGroup, reg *prometheus.Registry, config *config.Config, erroChan chan error, mock bool) *faultdetector.FaultDetector {
 	var fd *faultdetector.FaultDetector
 	if !mock {
-		fd, _ = faultdetector.NewFaultDetector(ctx, logger, erroChan, wg, config.FaultDetectorConfig, reg, testNotificationService)
+		fd, _ = faultdetector.NewFaultDetector(ctx, logger, erroChan, wg, config.FaultDetectorConfigs[0], reg, testNotificationService)
 	} else {
 		mx := new(sync.RWMutex)
 		metrics := faultdetector.NewFaultDetectorMetrics(reg)
@@ -161,11 +165,13 @@ func prepareConfig(t *testing.T) *config.Config {
 			BasePath:         "/api",
 			RegisterVersions: []string{"v1"},
 		},
-		FaultDetectorConfig: &config.FaultDetectorConfig{
-			L1RPCEndpoint:                 l1RpcApi,
-			L2RPCEndpoint:                 l2RpcApi,
-			StartBatchIndex:               -1,
-			L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+		FaultDetectorConfigs: []*config.FaultDetectorConfig{
+			{
+				L1RPCEndpoint:                 l1RpcApi,
+				L2RPCEndpoint:                 l2RpcApi,
+				StartBatchIndex:               -1,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+			},
 		},
 		Notification: &config.Notification{
 			Enable: true,
@@ -253,14 +259,14 @@ func TestMain_E2E(t *testing.T) {
 			testServer.RegisterHandler("GET", "/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry, ProcessStartTime: time.Now()}))
 
 			app := &App{
-				ctx:           ctx,
-				logger:        logger,
-				errChan:       errorChan,
-				config:        testConfig,
-				wg:            &wg,
-				apiServer:     testServer,
-				faultDetector: testFaultDetector,
-				notification:  testNotificationService,
+				ctx:            ctx,
+				logger:         logger,
+				errChan:        errorChan,
+				config:         testConfig,
+				wg:             &wg,
+				apiServer:      testServer,
+				faultDetectors: []*faultdetector.FaultDetector{testFaultDetector},
+				notification:   testNotificationService,
 			}
 
 			time.AfterFunc(5*time.Second, func() {
@@ -300,3 +306,372 @@ func TestMain_E2E(t *testing.T) {
 		})
 	}
 }
+
+const (
+	fakeChainName            = "devnet"
+	fakeChainPort            = 8089
+	fakeL2ChainID            = 901
+	fakeL2BlockTime          = 2
+	fakeSubmissionInterval   = 10
+	fakeL1BlockTime          = 12
+	fakeCheckInterval        = 50 * time.Millisecond
+	fakeNodeAssertionTimeout = 10 * time.Second
+)
+
+type fakeChainStatus struct {
+	Ok                    bool     `json:"ok"`
+	State                 string   `json:"state"`
+	DivergedOutputIndexes []uint64 `json:"divergedOutputIndexes"`
+}
+
+type fakeStatusResponse struct {
+	Ok     bool                       `json:"ok"`
+	Chains map[string]fakeChainStatus `json:"chains"`
+}
+
+// fakeChain holds the fake L1 node with the oracle and the fake L2 nodes the fault detector is run against.
+type fakeChain struct {
+	l1  *chaintest.L1
+	l2s []*chaintest.L2
+}
+
+func newFakeChain(l2Count int) *fakeChain {
+	genesisTime := uint64(time.Now().Add(-24 * time.Hour).Unix())
+	l2Config := chaintest.L2Config{ChainID: fakeL2ChainID, GenesisTime: genesisTime, BlockTime: fakeL2BlockTime}
+
+	chain := &fakeChain{
+		l1: chaintest.NewL1(chaintest.L1Config{
+			ChainID:     900,
+			GenesisTime: genesisTime,
+			BlockTime:   fakeL1BlockTime,
+			Oracle: chaintest.OracleConfig{
+				Address:                   common.HexToAddress("0x1111111111111111111111111111111111111111"),
+				StartingTimestamp:         genesisTime,
+				SubmissionInterval:        fakeSubmissionInterval,
+				L2BlockTime:               fakeL2BlockTime,
+				FinalizationPeriodSeconds: faultProofWindow,
+			},
+		}),
+	}
+	for i := 0; i < l2Count; i++ {
+		chain.l2s = append(chain.l2s, chaintest.NewL2(l2Config))
+	}
+	return chain
+}
+
+// proposeOutputs proposes the given number of outputs, with the output roots computed from the first L2 node.
+func (c *fakeChain) proposeOutputs(count int) {
+	for i := 0; i < count; i++ {
+		l2BlockNumber := c.l1.NextOutputBlockNumber()
+		for _, l2 := range c.l2s {
+			l2.Mine(l2BlockNumber)
+		}
+		c.l1.ProposeOutput(c.l2s[0].OutputRoot(l2BlockNumber), l2BlockNumber)
+	}
+}
+
+func (c *fakeChain) close() {
+	c.l1.Close()
+	for _, l2 := range c.l2s {
+		l2.Close()
+	}
+}
+
+func prepareFakeChainConfig(chain *fakeChain) *config.Config {
+	l2RPCEndpoints := make([]string, 0, len(chain.l2s))
+	for _, l2 := range chain.l2s {
+		l2RPCEndpoints = append(l2RPCEndpoints, l2.URL())
+	}
+
+	return &config.Config{
+		System: &config.System{
+			LogLevel: "info",
+		},
+		Api: &config.Api{
+			Server: &config.Server{
+				Host: host,
+				Port: fakeChainPort,
+			},
+			BasePath:         "/api",
+			RegisterVersions: []string{"v1"},
+		},
+		FaultDetectorConfigs: []*config.FaultDetectorConfig{
+			{
+				Name:                          fakeChainName,
+				L1RPCEndpoint:                 chain.l1.URL(),
+				L2RPCEndpoints:                l2RPCEndpoints,
+				StartBatchIndex:               0,
+				L2OutputOracleContractAddress: "0x1111111111111111111111111111111111111111",
+				Scheduler: &config.Scheduler{
+					BehindInterval:         fakeCheckInterval,
+					ProposalInterval:       fakeCheckInterval,
+					FaultRecheckInterval:   fakeCheckInterval,
+					L2NodeBehindInterval:   fakeCheckInterval,
+					BackoffInitialInterval: fakeCheckInterval,
+					BackoffMaxInterval:     fakeCheckInterval,
+				},
+			},
+		},
+		Notification: &config.Notification{
+			Enable: true,
+			Slack: &config.SlackConfig{
+				ChannelID: channelID,
+			},
+		},
+	}
+}
+
+func getFakeChainStatus(client *http.Client) (*fakeChainStatus, error) {
+	res, err := client.Get(fmt.Sprintf("http://%s:%d/api/v1/status", host, fakeChainPort))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var status fakeStatusResponse
+	if err := json.NewDecoder(res.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+	chainStatus, ok := status.Chains[fakeChainName]
+	if !ok {
+		return nil, fmt.Errorf("status of chain %s not found", fakeChainName)
+	}
+	return &chainStatus, nil
+}
+
+func getFakeChainStateMismatch(client *http.Client) (float64, error) {
+	res, err := client.Get(fmt.Sprintf("http://%s:%d/metrics", host, fakeChainPort))
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range parseMetricRes(strings.NewReader(string(body))) {
+		if metric, ok := m[faultDetectorStateMismatchMetricKey]; ok && metric["chain"] == fakeChainName {
+			return metric[metricValue].(float64), nil
+		}
+	}
+	return 0, errors.New("state mismatch metric not found")
+}
+
+// getFakeChainEvidence downloads the evidence bundle of the output with the given index, it returns nil when no evidence is archived.
+func getFakeChainEvidence(client *http.Client, outputIndex uint64) (*faultdetector.EvidenceBundle, error) {
+	res, err := client.Get(fmt.Sprintf("http://%s:%d/api/v1/faults/%s/%d/evidence", host, fakeChainPort, fakeChainName, outputIndex))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return faultdetector.DecodeEvidenceBundle(content)
+}
+
+func TestApp_FakeNodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &http.Client{Timeout: time.Second}
+
+	type step struct {
+		action                        func(chain *fakeChain)
+		expectedState                 string
+		expectedOk                    bool
+		expectedDivergedOutputIndexes []uint64
+	}
+
+	tests := []struct {
+		name                     string
+		l2Count                  int
+		steps                    []step
+		expectedStateMismatch    float64
+		expectedNotificationSent bool
+	}{
+		{
+			name:    "should verify every output proposed to the oracle and detect no fault",
+			l2Count: 1,
+			steps: []step{
+				{
+					action:                        func(chain *fakeChain) { chain.proposeOutputs(3) },
+					expectedState:                 string(faultdetector.StateIdle),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+			},
+			expectedStateMismatch: 0,
+		},
+		{
+			name:    "should detect the fault injected in an output proposed to the oracle",
+			l2Count: 1,
+			steps: []step{
+				{
+					action: func(chain *fakeChain) {
+						chain.proposeOutputs(2)
+						l2BlockNumber := chain.l1.NextOutputBlockNumber()
+						chain.l2s[0].Mine(l2BlockNumber)
+						chain.l1.ProposeOutput(randHash(), l2BlockNumber)
+					},
+					expectedState:                 string(faultdetector.StateDiverged),
+					expectedOk:                    false,
+					expectedDivergedOutputIndexes: []uint64{2},
+				},
+			},
+			expectedStateMismatch:    1,
+			expectedNotificationSent: true,
+		},
+		{
+			name:    "should wait for the lagging L2 node and verify the output once synced",
+			l2Count: 1,
+			steps: []step{
+				{
+					action: func(chain *fakeChain) {
+						chain.proposeOutputs(1)
+						// The output root is computed by the L2 node without serving the block
+						l2BlockNumber := chain.l1.NextOutputBlockNumber()
+						chain.l1.ProposeOutput(chain.l2s[0].OutputRoot(l2BlockNumber), l2BlockNumber)
+					},
+					expectedState:                 string(faultdetector.StateWaitingForL2Node),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+				{
+					action:                        func(chain *fakeChain) { chain.l2s[0].Mine(2 * fakeSubmissionInterval) },
+					expectedState:                 string(faultdetector.StateIdle),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+			},
+			expectedStateMismatch: 0,
+		},
+		{
+			name:    "should retry the failing RPC calls and recover",
+			l2Count: 1,
+			steps: []step{
+				{
+					action: func(chain *fakeChain) {
+						chain.l2s[0].FailMethod("eth_getProof", errors.New("internal error"))
+						chain.proposeOutputs(2)
+					},
+					expectedState:                 string(faultdetector.StateFailing),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+				{
+					action:                        func(chain *fakeChain) { chain.l2s[0].RecoverMethod("eth_getProof") },
+					expectedState:                 string(faultdetector.StateIdle),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+			},
+			expectedStateMismatch: 0,
+		},
+		{
+			name:    "should verify the outputs agreed on by the quorum when an L2 node serves a diverged state",
+			l2Count: 3,
+			steps: []step{
+				{
+					action: func(chain *fakeChain) {
+						chain.l2s[2].Diverge(1, "diverged")
+						chain.proposeOutputs(3)
+					},
+					expectedState:                 string(faultdetector.StateIdle),
+					expectedOk:                    true,
+					expectedDivergedOutputIndexes: []uint64{},
+				},
+			},
+			expectedStateMismatch:    0,
+			expectedNotificationSent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			wg := sync.WaitGroup{}
+			logger, err := log.NewDefaultProductionLogger()
+			require.NoError(t, err)
+
+			chain := newFakeChain(tt.l2Count)
+			defer chain.close()
+
+			errorChan := make(chan error, 1)
+			registry := prometheus.NewRegistry()
+			testConfig := prepareFakeChainConfig(chain)
+			testConfig.FaultDetectorConfigs[0].Evidence = &config.Evidence{Enable: true, Directory: t.TempDir()}
+			testServer := prepareHTTPServer(t, ctx, logger, testConfig, &wg, errorChan)
+			testServer.RegisterHandler("GET", "/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry, ProcessStartTime: time.Now()}))
+			slackClient := new(mockSlackClient)
+			slackClient.On(postMessageContextFnName).Return(channelID, "1234569.1000", nil)
+			testNotificationService := notification.GetNotification(ctx, logger, slackClient, testConfig.Notification)
+
+			// The fault detector is created against the fake nodes over JSON-RPC
+			testFaultDetector, err := faultdetector.NewFaultDetector(ctx, logger, errorChan, &wg, testConfig.FaultDetectorConfigs[0], registry, testNotificationService)
+			require.NoError(t, err)
+
+			app := &App{
+				ctx:            ctx,
+				logger:         logger,
+				errChan:        errorChan,
+				config:         testConfig,
+				wg:             &wg,
+				apiServer:      testServer,
+				faultDetectors: []*faultdetector.FaultDetector{testFaultDetector},
+				notification:   testNotificationService,
+			}
+			done := make(chan struct{})
+			go func() {
+				app.Start()
+				close(done)
+			}()
+			defer func() {
+				app.stop()
+				<-done
+			}()
+
+			for _, step := range tt.steps {
+				step.action(chain)
+				require.Eventually(t, func() bool {
+					status, err := getFakeChainStatus(client)
+					return err == nil && status.State == step.expectedState
+				}, fakeNodeAssertionTimeout, fakeCheckInterval)
+
+				status, err := getFakeChainStatus(client)
+				require.NoError(t, err)
+				require.Equal(t, step.expectedOk, status.Ok)
+				require.Equal(t, step.expectedDivergedOutputIndexes, status.DivergedOutputIndexes)
+			}
+
+			stateMismatch, err := getFakeChainStateMismatch(client)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStateMismatch, stateMismatch)
+			if tt.expectedNotificationSent {
+				slackClient.AssertCalled(t, postMessageContextFnName)
+			} else {
+				slackClient.AssertNotCalled(t, postMessageContextFnName)
+			}
+
+			// The evidence of every diverged output is archived and downloadable, and verifies offline to the same verdict
+			evidence, err := getFakeChainEvidence(client, 0)
+			require.NoError(t, err)
+			require.Nil(t, evidence)
+			for _, outputIndex := range tt.steps[len(tt.steps)-1].expectedDivergedOutputIndexes {
+				evidence, err := getFakeChainEvidence(client, outputIndex)
+				require.NoError(t, err)
+				require.NotNil(t, evidence)
+				require.NotNil(t, evidence.L1Block)
+				require.Len(t, evidence.Endpoints.L2, tt.l2Count)
+				require.Equal(t, faultdetector.VerdictMismatch, faultdetector.VerifyEvidence(evidence).Verdict)
+			}
+		})
+	}
+}
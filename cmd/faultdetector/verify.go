@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+)
+
+const (
+	verifyCommand = "verify"
+
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
+var errAuditFailed = errors.New("audit found outputs that could not be verified or do not match")
+
+// verifyOptions are the command line options of the `verify` subcommand.
+type verifyOptions struct {
+	configFilepath string
+	chainName      string
+	fromIndex      int64
+	toIndex        int64
+	fromBlock      int64
+	toBlock        int64
+	format         string
+	outputFilepath string
+	workers        uint
+}
+
+// parseVerifyOptions parses and validates the command line options of the `verify` subcommand.
+func parseVerifyOptions(args []string) (*verifyOptions, error) {
+	opts := &verifyOptions{}
+	flags := flag.NewFlagSet(verifyCommand, flag.ContinueOnError)
+	flags.StringVar(&opts.configFilepath, "config", "./config.yaml", "Path to the config file")
+	flags.StringVar(&opts.chainName, "chain", "", "Name of the chain to verify, required when multiple chains are configured")
+	flags.Int64Var(&opts.fromIndex, "from", -1, "First output index to verify")
+	flags.Int64Var(&opts.toIndex, "to", -1, "Last output index to verify")
+	flags.Int64Var(&opts.fromBlock, "from-block", -1, "First L2 block number of the outputs to verify, alternative to --from")
+	flags.Int64Var(&opts.toBlock, "to-block", -1, "Last L2 block number of the outputs to verify, alternative to --to")
+	flags.StringVar(&opts.format, "format", reportFormatJSON, "Format of the report, either json or csv")
+	flags.StringVar(&opts.outputFilepath, "output", "", "Path to the report file")
+	flags.UintVar(&opts.workers, "workers", 1, "Number of outputs verified concurrently")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	byIndex := opts.fromIndex >= 0 && opts.toIndex >= 0
+	byBlock := opts.fromBlock >= 0 && opts.toBlock >= 0
+	if byIndex == byBlock {
+		return nil, fmt.Errorf("either --from and --to or --from-block and --to-block are required")
+	}
+	if opts.format != reportFormatJSON && opts.format != reportFormatCSV {
+		return nil, fmt.Errorf("--format expected one of [%s %s], received: '%s'", reportFormatJSON, reportFormatCSV, opts.format)
+	}
+	if len(opts.outputFilepath) == 0 {
+		return nil, fmt.Errorf("--output is required")
+	}
+
+	return opts, nil
+}
+
+// runVerify verifies the range of outputs given by the command line arguments and writes the report.
+// It returns [errAuditFailed] when any output in the range could not be verified or does not match.
+func runVerify(ctx context.Context, logger log.Logger, args []string) error {
+	opts, err := parseVerifyOptions(args)
+	if err != nil {
+		return err
+	}
+
+	appConfig, err := getAppConfig(logger, opts.configFilepath)
+	if err != nil {
+		return err
+	}
+
+	faultDetectorConfig, err := selectFaultDetectorConfig(appConfig.FaultDetectorConfigs, opts.chainName)
+	if err != nil {
+		return err
+	}
+
+	auditor, err := faultdetector.NewAuditor(ctx, logger, faultDetectorConfig, opts.workers)
+	if err != nil {
+		return err
+	}
+
+	fromIndex, toIndex := uint64(opts.fromIndex), uint64(opts.toIndex)
+	if opts.fromBlock >= 0 {
+		fromIndex, toIndex, err = auditor.FindOutputIndexRange(uint64(opts.fromBlock), uint64(opts.toBlock))
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("Verifying outputs with index from %d to %d.", fromIndex, toIndex)
+	audits, err := auditor.AuditOutputs(fromIndex, toIndex)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(opts.outputFilepath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := writeReport(file, opts.format, audits); err != nil {
+		return err
+	}
+	logger.Infof("Report of %d outputs written to %s.", len(audits), opts.outputFilepath)
+
+	for _, audit := range audits {
+		if audit.Verdict != faultdetector.VerdictOk && audit.Verdict != faultdetector.VerdictSkipped {
+			return errAuditFailed
+		}
+	}
+
+	return nil
+}
+
+// selectFaultDetectorConfig returns the config of the chain with the given name, or the only configured chain when no name is given.
+func selectFaultDetectorConfig(faultDetectorConfigs []*config.FaultDetectorConfig, chainName string) (*config.FaultDetectorConfig, error) {
+	if len(chainName) == 0 {
+		if len(faultDetectorConfigs) != 1 {
+			return nil, fmt.Errorf("--chain is required when multiple chains are configured")
+		}
+		return faultDetectorConfigs[0], nil
+	}
+
+	for _, faultDetectorConfig := range faultDetectorConfigs {
+		if faultDetectorConfig.Name == chainName {
+			return faultDetectorConfig, nil
+		}
+	}
+
+	return nil, fmt.Errorf("chain %s is not configured", chainName)
+}
+
+// writeReport writes the audited outputs in the given format.
+func writeReport(w io.Writer, format string, audits []*faultdetector.OutputAudit) error {
+	if format == reportFormatCSV {
+		return writeCSVReport(w, audits)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(audits)
+}
+
+func writeCSVReport(w io.Writer, audits []*faultdetector.OutputAudit) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"outputIndex", "l2BlockNumber", "l1Timestamp", "expectedOutputRoot", "calculatedOutputRoot", "verdict", "error"}); err != nil {
+		return err
+	}
+
+	for _, audit := range audits {
+		record := []string{
+			strconv.FormatUint(audit.OutputIndex, 10),
+			strconv.FormatUint(audit.L2BlockNumber, 10),
+			strconv.FormatUint(audit.L1Timestamp, 10),
+			audit.ExpectedOutputRoot,
+			audit.CalculatedOutputRoot,
+			audit.Verdict,
+			audit.Error,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
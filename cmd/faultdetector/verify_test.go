@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVerifyOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr bool
+	}{
+		{
+			name: "should parse the output index range",
+			args: []string{"--from", "1", "--to", "5", "--output", "report.json"},
+		},
+		{
+			name: "should parse the L2 block range",
+			args: []string{"--from-block", "100", "--to-block", "500", "--format", "csv", "--output", "report.csv"},
+		},
+		{
+			name:        "should return error when no range is given",
+			args:        []string{"--output", "report.json"},
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when both ranges are given",
+			args:        []string{"--from", "1", "--to", "5", "--from-block", "100", "--to-block", "500", "--output", "report.json"},
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the format is unknown",
+			args:        []string{"--from", "1", "--to", "5", "--format", "xml", "--output", "report.xml"},
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the output file is missing",
+			args:        []string{"--from", "1", "--to", "5"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseVerifyOptions(test.args)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSelectFaultDetectorConfig(t *testing.T) {
+	configs := []*config.FaultDetectorConfig{{Name: "mainnet"}, {Name: "sepolia"}}
+
+	selected, err := selectFaultDetectorConfig(configs, "sepolia")
+	require.NoError(t, err)
+	require.Equal(t, "sepolia", selected.Name)
+
+	_, err = selectFaultDetectorConfig(configs, "")
+	require.Error(t, err)
+
+	_, err = selectFaultDetectorConfig(configs, "goerli")
+	require.Error(t, err)
+
+	selected, err = selectFaultDetectorConfig(configs[:1], "")
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", selected.Name)
+}
+
+func TestWriteReport(t *testing.T) {
+	audits := []*faultdetector.OutputAudit{
+		{OutputIndex: 1, L2BlockNumber: 100, L1Timestamp: 1000, ExpectedOutputRoot: "0x01", CalculatedOutputRoot: "0x01", Verdict: faultdetector.VerdictOk},
+		{OutputIndex: 2, Verdict: faultdetector.VerdictError, Error: "Failed to fetch output"},
+	}
+
+	var jsonReport bytes.Buffer
+	require.NoError(t, writeReport(&jsonReport, reportFormatJSON, audits))
+	var decoded []*faultdetector.OutputAudit
+	require.NoError(t, json.Unmarshal(jsonReport.Bytes(), &decoded))
+	require.Equal(t, audits, decoded)
+
+	var csvReport bytes.Buffer
+	require.NoError(t, writeReport(&csvReport, reportFormatCSV, audits))
+	lines := strings.Split(strings.TrimSpace(csvReport.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "1,100,1000,0x01,0x01,ok,", lines[1])
+	require.Equal(t, "2,0,0,,,error,Failed to fetch output", lines[2])
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gin-gonic/gin"
+)
+
+const evidenceFormatRLP = "rlp"
+
+// EvidenceLoader returns the archived evidence bundles of every proposal of the output with the given index, in the order of L1 timestamp.
+type EvidenceLoader func(outputIndex uint64) ([]*faultdetector.EvidenceBundle, error)
+
+// GetEvidence is the handler for the 'GET /api/v1/faults/:chain/:outputIndex/evidence' endpoint.
+// It returns the evidence bundle of the latest proposal of the diverged output as a file download, or of the proposal with the given `l1Timestamp` query parameter.
+// The bundle is JSON encoded, or RLP encoded with the `format=rlp` query parameter.
+func GetEvidence(c *gin.Context, evidenceByChain map[string]EvidenceLoader) {
+	chainName := c.Param("chain")
+	loadEvidence, ok := evidenceByChain[chainName]
+	if !ok {
+		c.IndentedJSON(http.StatusNotFound, errorResponse{Error: fmt.Sprintf("chain %s is not monitored", chainName)})
+		return
+	}
+
+	outputIndex, err := strconv.ParseUint(c.Param("outputIndex"), 10, 64)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("outputIndex expected to be a non-negative integer, received: '%s'", c.Param("outputIndex"))})
+		return
+	}
+
+	format := c.Query("format")
+	if len(format) > 0 && format != evidenceFormatRLP {
+		c.IndentedJSON(http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("format expected to be %s when given, received: '%s'", evidenceFormatRLP, format)})
+		return
+	}
+
+	var l1Timestamp uint64
+	if value, ok := c.GetQuery("l1Timestamp"); ok {
+		if l1Timestamp, err = strconv.ParseUint(value, 10, 64); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("l1Timestamp expected to be a non-negative integer, received: '%s'", value)})
+			return
+		}
+	}
+
+	bundles, err := loadEvidence(outputIndex)
+	if errors.Is(err, faultdetector.ErrEvidenceDisabled) {
+		c.IndentedJSON(http.StatusNotFound, errorResponse{Error: fmt.Sprintf("evidence is not enabled for chain %s", chainName)})
+		return
+	}
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+
+	var bundle *faultdetector.EvidenceBundle
+	for _, b := range bundles {
+		if l1Timestamp == 0 || b.Output.L1Timestamp == l1Timestamp {
+			bundle = b
+		}
+	}
+	if bundle == nil {
+		c.IndentedJSON(http.StatusNotFound, errorResponse{Error: fmt.Sprintf("no evidence of output index %d found for chain %s", outputIndex, chainName)})
+		return
+	}
+
+	filename := fmt.Sprintf("evidence_%d_%d_%d", bundle.L2ChainID, bundle.Output.OutputIndex, bundle.Output.L1Timestamp)
+	if format == evidenceFormatRLP {
+		content, err := rlp.EncodeToBytes(bundle)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.rlp", filename))
+		c.Data(http.StatusOK, "application/octet-stream", content)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", filename))
+	c.IndentedJSON(http.StatusOK, bundle)
+}
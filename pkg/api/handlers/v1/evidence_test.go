@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEvidenceBundle returns the evidence bundle of the output with index 11 proposed at the given L1 timestamp.
+func newTestEvidenceBundle(l1Timestamp uint64) *faultdetector.EvidenceBundle {
+	return &faultdetector.EvidenceBundle{
+		ChainName: "mainnet",
+		L2ChainID: 4202,
+		Output: faultdetector.EvidenceOutput{
+			OutputIndex:   11,
+			OutputRoot:    common.HexToHash("0x01"),
+			L2BlockNumber: 1800,
+			L1Timestamp:   l1Timestamp,
+		},
+		Header: &types.Header{Number: big.NewInt(1800), Difficulty: big.NewInt(0)},
+		Proof: &chain.ProofResponse{
+			Address:      common.HexToAddress(chain.L2BedrockMessagePasserAddress),
+			AccountProof: []hexutil.Bytes{{0x01}},
+			Balance:      (*hexutil.Big)(big.NewInt(0)),
+			StorageHash:  common.HexToHash("0x02"),
+			StorageProof: []common.Hash{},
+		},
+	}
+}
+
+// getEvidence serves the 'GET /api/v1/faults/:chain/:outputIndex/evidence' request with the given path and query string.
+func getEvidence(t *testing.T, path string, evidenceByChain map[string]EvidenceLoader) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/faults/:chain/:outputIndex/evidence", func(c *gin.Context) {
+		GetEvidence(c, evidenceByChain)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetEvidence(t *testing.T) {
+	evidenceByChain := map[string]EvidenceLoader{
+		"mainnet": func(outputIndex uint64) ([]*faultdetector.EvidenceBundle, error) {
+			if outputIndex != 11 {
+				return nil, nil
+			}
+			return []*faultdetector.EvidenceBundle{newTestEvidenceBundle(1400), newTestEvidenceBundle(1500)}, nil
+		},
+		"sepolia": func(outputIndex uint64) ([]*faultdetector.EvidenceBundle, error) {
+			return nil, faultdetector.ErrEvidenceDisabled
+		},
+		"devnet": func(outputIndex uint64) ([]*faultdetector.EvidenceBundle, error) {
+			return nil, errors.New("failed to read evidence directory")
+		},
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedStatus      int
+		expectedL1Timestamp uint64
+		expectedFilename    string
+	}{
+		{
+			name:                "should return the JSON evidence bundle of the latest proposal",
+			path:                "/api/v1/faults/mainnet/11/evidence",
+			expectedStatus:      http.StatusOK,
+			expectedL1Timestamp: 1500,
+			expectedFilename:    "evidence_4202_11_1500.json",
+		},
+		{
+			name:                "should return the JSON evidence bundle of the proposal with the given L1 timestamp",
+			path:                "/api/v1/faults/mainnet/11/evidence?l1Timestamp=1400",
+			expectedStatus:      http.StatusOK,
+			expectedL1Timestamp: 1400,
+			expectedFilename:    "evidence_4202_11_1400.json",
+		},
+		{
+			name:                "should return the RLP evidence bundle",
+			path:                "/api/v1/faults/mainnet/11/evidence?format=rlp&l1Timestamp=1400",
+			expectedStatus:      http.StatusOK,
+			expectedL1Timestamp: 1400,
+			expectedFilename:    "evidence_4202_11_1400.rlp",
+		},
+		{
+			name:           "should return not found when no proposal has the given L1 timestamp",
+			path:           "/api/v1/faults/mainnet/11/evidence?l1Timestamp=1600",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return not found when no evidence is archived for the output index",
+			path:           "/api/v1/faults/mainnet/12/evidence",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return not found when the chain is not monitored",
+			path:           "/api/v1/faults/unknown/11/evidence",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return not found when the evidence is not enabled for the chain",
+			path:           "/api/v1/faults/sepolia/11/evidence",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return bad request when the output index is not a number",
+			path:           "/api/v1/faults/mainnet/latest/evidence",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request when the format is unknown",
+			path:           "/api/v1/faults/mainnet/11/evidence?format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request when the L1 timestamp is not a number",
+			path:           "/api/v1/faults/mainnet/11/evidence?l1Timestamp=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return internal server error when the evidence can not be loaded",
+			path:           "/api/v1/faults/devnet/11/evidence",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := getEvidence(t, test.path, evidenceByChain)
+			require.Equal(t, test.expectedStatus, recorder.Code)
+
+			if test.expectedStatus != http.StatusOK {
+				var response errorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.NotEmpty(t, response.Error)
+				return
+			}
+
+			require.Equal(t, "attachment; filename="+test.expectedFilename, recorder.Header().Get("Content-Disposition"))
+			bundle, err := faultdetector.DecodeEvidenceBundle(recorder.Body.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, test.expectedL1Timestamp, bundle.Output.L1Timestamp)
+			require.Equal(t, newTestEvidenceBundle(test.expectedL1Timestamp).Output, bundle.Output)
+
+			if strings.HasSuffix(test.expectedFilename, ".rlp") {
+				require.Equal(t, "application/octet-stream", recorder.Header().Get("Content-Type"))
+				expected, err := rlp.EncodeToBytes(newTestEvidenceBundle(test.expectedL1Timestamp))
+				require.NoError(t, err)
+				require.Equal(t, expected, recorder.Body.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultFaultsLimit = 100
+	maxFaultsLimit     = 1000
+)
+
+var faultResolutions = []string{
+	faultdetector.FaultResolutionUnresolved,
+	faultdetector.FaultResolutionVerified,
+	faultdetector.FaultResolutionDeleted,
+	faultdetector.FaultResolutionReorged,
+	faultdetector.FaultResolutionChallenged,
+}
+
+type faultsMetaResponse struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type faultsResponse struct {
+	Faults []*faultdetector.FaultRecord `json:"faults"`
+	Meta   faultsMetaResponse           `json:"meta"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// faultsFilter holds the query parameters of the 'GET /api/v1/faults' endpoint.
+type faultsFilter struct {
+	chain           string
+	resolution      string
+	fromOutputIndex uint64
+	toOutputIndex   uint64
+	offset          int
+	limit           int
+}
+
+// parseFaultsFilter parses and validates the query parameters of the 'GET /api/v1/faults' endpoint.
+func parseFaultsFilter(c *gin.Context) (*faultsFilter, error) {
+	filter := &faultsFilter{
+		chain:         c.Query("chain"),
+		resolution:    c.Query("resolution"),
+		toOutputIndex: math.MaxUint64,
+		limit:         defaultFaultsLimit,
+	}
+
+	if len(filter.resolution) > 0 && !isFaultResolution(filter.resolution) {
+		return nil, fmt.Errorf("resolution expected one of %v, received: '%s'", faultResolutions, filter.resolution)
+	}
+
+	var err error
+	if value, ok := c.GetQuery("fromOutputIndex"); ok {
+		if filter.fromOutputIndex, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("fromOutputIndex expected to be a non-negative integer, received: '%s'", value)
+		}
+	}
+	if value, ok := c.GetQuery("toOutputIndex"); ok {
+		if filter.toOutputIndex, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("toOutputIndex expected to be a non-negative integer, received: '%s'", value)
+		}
+	}
+	if value, ok := c.GetQuery("offset"); ok {
+		if filter.offset, err = strconv.Atoi(value); err != nil || filter.offset < 0 {
+			return nil, fmt.Errorf("offset expected to be a non-negative integer, received: '%s'", value)
+		}
+	}
+	if value, ok := c.GetQuery("limit"); ok {
+		if filter.limit, err = strconv.Atoi(value); err != nil || filter.limit < 1 || filter.limit > maxFaultsLimit {
+			return nil, fmt.Errorf("limit expected in range: 1 - %d, received: '%s'", maxFaultsLimit, value)
+		}
+	}
+
+	return filter, nil
+}
+
+func isFaultResolution(resolution string) bool {
+	for _, faultResolution := range faultResolutions {
+		if resolution == faultResolution {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true when the fault record satisfies all the filters.
+func (f *faultsFilter) matches(record *faultdetector.FaultRecord) bool {
+	return (len(f.chain) == 0 || record.ChainName == f.chain) &&
+		(len(f.resolution) == 0 || record.Resolution == f.resolution) &&
+		record.OutputIndex >= f.fromOutputIndex &&
+		record.OutputIndex <= f.toOutputIndex
+}
+
+// GetFaults is the handler for the 'GET /api/v1/faults' endpoint.
+// It returns the faults detected on all the monitored chains, most recently detected first, filtered and paginated by the query parameters.
+func GetFaults(c *gin.Context, faultsByChain map[string][]*faultdetector.FaultRecord) {
+	filter, err := parseFaultsFilter(c)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	if _, ok := faultsByChain[filter.chain]; len(filter.chain) > 0 && !ok {
+		c.IndentedJSON(http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("chain %s is not monitored", filter.chain)})
+		return
+	}
+
+	faults := []*faultdetector.FaultRecord{}
+	for _, records := range faultsByChain {
+		for _, record := range records {
+			if filter.matches(record) {
+				faults = append(faults, record)
+			}
+		}
+	}
+	sort.SliceStable(faults, func(i, j int) bool {
+		if faults[i].FirstSeenAt.Equal(faults[j].FirstSeenAt) {
+			if faults[i].ChainName == faults[j].ChainName {
+				return faults[i].OutputIndex > faults[j].OutputIndex
+			}
+			return faults[i].ChainName < faults[j].ChainName
+		}
+		return faults[i].FirstSeenAt.After(faults[j].FirstSeenAt)
+	})
+
+	total := len(faults)
+	start := min(filter.offset, total)
+	end := min(start+filter.limit, total)
+	c.IndentedJSON(http.StatusOK, faultsResponse{
+		Faults: faults[start:end],
+		Meta: faultsMetaResponse{
+			Total:  total,
+			Offset: filter.offset,
+			Limit:  filter.limit,
+		},
+	})
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/faultdetector"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFaults returns the faults of two monitored chains, output index 1 of each chain being detected first.
+func newTestFaults() map[string][]*faultdetector.FaultRecord {
+	firstSeenAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	faultsByChain := map[string][]*faultdetector.FaultRecord{}
+	for _, chainName := range []string{"mainnet", "sepolia"} {
+		for outputIndex := uint64(1); outputIndex <= 3; outputIndex++ {
+			resolution := faultdetector.FaultResolutionVerified
+			if outputIndex == 3 {
+				resolution = faultdetector.FaultResolutionUnresolved
+			}
+			faultsByChain[chainName] = append(faultsByChain[chainName], &faultdetector.FaultRecord{
+				ChainName:   chainName,
+				OutputIndex: outputIndex,
+				FirstSeenAt: firstSeenAt.Add(time.Duration(outputIndex) * time.Hour),
+				Resolution:  resolution,
+			})
+		}
+	}
+	return faultsByChain
+}
+
+// getFaults serves the 'GET /api/v1/faults' request with the given query string.
+func getFaults(t *testing.T, query string, faultsByChain map[string][]*faultdetector.FaultRecord) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/faults", func(c *gin.Context) {
+		GetFaults(c, faultsByChain)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/faults"+query, nil)
+	require.NoError(t, err)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// faultKey identifies a fault in the response.
+type faultKey struct {
+	chain       string
+	outputIndex uint64
+}
+
+func TestGetFaults(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFaults []faultKey
+		expectedMeta   faultsMetaResponse
+	}{
+		{
+			name:  "should return the faults of every chain, most recently detected first",
+			query: "",
+			expectedFaults: []faultKey{
+				{"mainnet", 3}, {"sepolia", 3}, {"mainnet", 2}, {"sepolia", 2}, {"mainnet", 1}, {"sepolia", 1},
+			},
+			expectedMeta: faultsMetaResponse{Total: 6, Offset: 0, Limit: defaultFaultsLimit},
+		},
+		{
+			name:           "should filter the faults by chain and resolution",
+			query:          "?chain=sepolia&resolution=verified",
+			expectedFaults: []faultKey{{"sepolia", 2}, {"sepolia", 1}},
+			expectedMeta:   faultsMetaResponse{Total: 2, Offset: 0, Limit: defaultFaultsLimit},
+		},
+		{
+			name:           "should filter the faults by range of output indexes",
+			query:          "?chain=mainnet&fromOutputIndex=2&toOutputIndex=2",
+			expectedFaults: []faultKey{{"mainnet", 2}},
+			expectedMeta:   faultsMetaResponse{Total: 1, Offset: 0, Limit: defaultFaultsLimit},
+		},
+		{
+			name:           "should paginate the faults",
+			query:          "?offset=1&limit=2",
+			expectedFaults: []faultKey{{"sepolia", 3}, {"mainnet", 2}},
+			expectedMeta:   faultsMetaResponse{Total: 6, Offset: 1, Limit: 2},
+		},
+		{
+			name:           "should return no fault when the offset is past the total",
+			query:          "?offset=10",
+			expectedFaults: []faultKey{},
+			expectedMeta:   faultsMetaResponse{Total: 6, Offset: 10, Limit: defaultFaultsLimit},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := getFaults(t, test.query, newTestFaults())
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response faultsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			faults := []faultKey{}
+			for _, fault := range response.Faults {
+				faults = append(faults, faultKey{fault.ChainName, fault.OutputIndex})
+			}
+			require.Equal(t, test.expectedFaults, faults)
+			require.Equal(t, test.expectedMeta, response.Meta)
+		})
+	}
+}
+
+func TestGetFaults_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{
+			name:          "should return error when the chain is not monitored",
+			query:         "?chain=devnet",
+			expectedError: "chain devnet is not monitored",
+		},
+		{
+			name:          "should return error when the resolution is unknown",
+			query:         "?resolution=ignored",
+			expectedError: "resolution expected one of [unresolved verified deleted reorged challenged], received: 'ignored'",
+		},
+		{
+			name:          "should return error when the output index is negative",
+			query:         "?fromOutputIndex=-1",
+			expectedError: "fromOutputIndex expected to be a non-negative integer, received: '-1'",
+		},
+		{
+			name:          "should return error when the offset is negative",
+			query:         "?offset=-1",
+			expectedError: "offset expected to be a non-negative integer, received: '-1'",
+		},
+		{
+			name:          "should return error when the limit is zero",
+			query:         "?limit=0",
+			expectedError: "limit expected in range: 1 - 1000, received: '0'",
+		},
+		{
+			name:          "should return error when the limit is above the maximum",
+			query:         "?limit=1001",
+			expectedError: "limit expected in range: 1 - 1000, received: '1001'",
+		},
+		{
+			name:          "should return error when the limit is not a number",
+			query:         "?limit=all",
+			expectedError: "limit expected in range: 1 - 1000, received: 'all'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := getFaults(t, test.query, newTestFaults())
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var response errorResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			require.Equal(t, test.expectedError, response.Error)
+		})
+	}
+}
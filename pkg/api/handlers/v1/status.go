@@ -2,18 +2,46 @@ package v1
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ChainStatus holds the status of the fault detector monitoring a single chain.
+type ChainStatus struct {
+	IsFaultDetected       bool
+	State                 string
+	StateSince            time.Time
+	DivergedOutputIndexes []uint64
+}
+
+type chainStatusResponse struct {
+	Ok                    bool      `json:"ok"`
+	State                 string    `json:"state"`
+	StateSince            time.Time `json:"stateSince"`
+	DivergedOutputIndexes []uint64  `json:"divergedOutputIndexes"`
+}
+
 type statusResponse struct {
-	Ok bool `json:"ok"`
+	Ok     bool                           `json:"ok"`
+	Chains map[string]chainStatusResponse `json:"chains"`
 }
 
 // GetStatus is the handler for the 'GET /api/v1/status' endpoint.
-func GetStatus(c *gin.Context, isFaultDetected bool) {
+// The aggregated status is ok only when no fault is detected on any of the monitored chains.
+func GetStatus(c *gin.Context, statusByChain map[string]ChainStatus) {
 	status := statusResponse{
-		Ok: !isFaultDetected,
+		Ok:     true,
+		Chains: make(map[string]chainStatusResponse, len(statusByChain)),
+	}
+	for chainName, chainStatus := range statusByChain {
+		status.Chains[chainName] = chainStatusResponse{
+			Ok:                    !chainStatus.IsFaultDetected,
+			State:                 chainStatus.State,
+			StateSince:            chainStatus.StateSince,
+			DivergedOutputIndexes: chainStatus.DivergedOutputIndexes,
+		}
+		status.Ok = status.Ok && !chainStatus.IsFaultDetected
 	}
 	c.IndentedJSON(http.StatusOK, status)
 }
@@ -35,14 +35,42 @@ func registerHandlers(logger log.Logger, router *gin.Engine) {
 }
 
 // RegisterHandlersForVersions is responsible to register API version specific route handlers.
-func (w *HTTPServer) RegisterHandlersForVersions(fd *faultdetector.FaultDetector, versions []string, basePath string) {
+func (w *HTTPServer) RegisterHandlersForVersions(fds []*faultdetector.FaultDetector, versions []string, basePath string) {
 	baseGroup := w.router.Group(basePath)
 	for _, version := range versions {
 		group := baseGroup.Group(version)
 		switch version {
 		case "v1":
 			group.GET("/status", func(c *gin.Context) {
-				v1.GetStatus(c, fd.IsFaultDetected())
+				statusByChain := make(map[string]v1.ChainStatus, len(fds))
+				for _, fd := range fds {
+					state, stateSince := fd.State()
+					divergedOutputIndexes := []uint64{}
+					for _, divergedOutput := range fd.DivergedOutputs() {
+						divergedOutputIndexes = append(divergedOutputIndexes, divergedOutput.OutputIndex)
+					}
+					statusByChain[fd.ChainName()] = v1.ChainStatus{
+						IsFaultDetected:       fd.IsFaultDetected(),
+						State:                 string(state),
+						StateSince:            stateSince,
+						DivergedOutputIndexes: divergedOutputIndexes,
+					}
+				}
+				v1.GetStatus(c, statusByChain)
+			})
+			group.GET("/faults", func(c *gin.Context) {
+				faultsByChain := make(map[string][]*faultdetector.FaultRecord, len(fds))
+				for _, fd := range fds {
+					faultsByChain[fd.ChainName()] = fd.FaultHistory()
+				}
+				v1.GetFaults(c, faultsByChain)
+			})
+			group.GET("/faults/:chain/:outputIndex/evidence", func(c *gin.Context) {
+				evidenceByChain := make(map[string]v1.EvidenceLoader, len(fds))
+				for _, fd := range fds {
+					evidenceByChain[fd.ChainName()] = fd.Evidence
+				}
+				v1.GetEvidence(c, evidenceByChain)
 			})
 
 		default:
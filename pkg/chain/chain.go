@@ -66,6 +66,11 @@ func (c *ChainAPIClient) GetBlockHeaderByNumber(ctx context.Context, blockNumber
 	return c.eth.HeaderByNumber(ctx, blockNumber)
 }
 
+// GetBlockHeaderByHash returns block header for a given block hash from a connected node.
+func (c *ChainAPIClient) GetBlockHeaderByHash(ctx context.Context, blockHash common.Hash) (*types.Header, error) {
+	return c.eth.HeaderByHash(ctx, blockHash)
+}
+
 // GetLatestBlockHeader returns latest block header from a connected node.
 func (c *ChainAPIClient) GetLatestBlockHeader(ctx context.Context) (*types.Header, error) {
 	blockNumber, err := c.eth.BlockNumber(ctx)
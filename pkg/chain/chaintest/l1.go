@@ -0,0 +1,455 @@
+package chaintest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// implementationSlot is the EIP-1967 storage slot of the proxy implementation address.
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// errExecutionReverted is returned by the calls reverted by the fake oracle.
+var errExecutionReverted = errors.New("execution reverted")
+
+// OracleConfig holds the address and the parameters of the fake `L2OutputOracle` contract.
+type OracleConfig struct {
+	Address                   common.Address
+	Implementation            common.Address
+	StartingBlockNumber       uint64
+	StartingTimestamp         uint64
+	SubmissionInterval        uint64
+	L2BlockTime               uint64
+	FinalizationPeriodSeconds uint64
+	Proposer                  common.Address
+	Challenger                common.Address
+}
+
+// L1Config holds the parameters of a fake L1 chain and of the oracle deployed on it.
+type L1Config struct {
+	ChainID     uint64
+	GenesisTime uint64
+	BlockTime   uint64
+	Oracle      OracleConfig
+}
+
+// L1 is a fake L1 node with the `L2OutputOracle` contract deployed. Every proposal or deletion of outputs is included in a new L1 block.
+// The oracle calls are served at the requested L1 block, the `OutputProposed` and `OutputsDeleted` events are served as logs.
+// The L1 transactions are not served, i.e. the proposer of the outputs can not be looked up.
+type L1 struct {
+	*Node
+	config  L1Config
+	abi     *abi.ABI
+	mutex   sync.RWMutex
+	headers []*types.Header
+	events  []*oracleEvent
+}
+
+// oracleEvent is a proposal or a deletion of outputs, applied to the oracle state from its L1 block onwards.
+type oracleEvent struct {
+	l1BlockNumber       uint64
+	outputIndex         uint64
+	proposal            *bindings.TypesOutputProposal
+	prevNextOutputIndex uint64
+	newNextOutputIndex  uint64
+}
+
+// NewL1 starts a fake L1 node with the genesis block only and no output proposed.
+func NewL1(config L1Config) *L1 {
+	oracleABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	l1 := &L1{
+		config: config,
+		abi:    oracleABI,
+	}
+	l1.headers = []*types.Header{l1.newHeader(0, common.Hash{})}
+	l1.Node = newNode(&l1Service{l1: l1})
+	return l1
+}
+
+// MineBlock appends an empty block to the chain and returns its height.
+func (l *L1) MineBlock() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.mineLocked()
+}
+
+// ProposeOutput proposes the given output root for the L2 block with the given height in a new L1 block and returns the index of the output.
+func (l *L1) ProposeOutput(outputRoot common.Hash, l2BlockNumber uint64) uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l1BlockNumber := l.mineLocked()
+	outputIndex := uint64(len(l.outputsLocked(l1BlockNumber)))
+	l.events = append(l.events, &oracleEvent{
+		l1BlockNumber: l1BlockNumber,
+		outputIndex:   outputIndex,
+		proposal: &bindings.TypesOutputProposal{
+			OutputRoot:    outputRoot,
+			Timestamp:     new(big.Int).SetUint64(l.headers[l1BlockNumber].Time),
+			L2BlockNumber: new(big.Int).SetUint64(l2BlockNumber),
+		},
+	})
+	return outputIndex
+}
+
+// NextOutputBlockNumber returns the height of the L2 block the next output is expected to be proposed for.
+func (l *L1) NextOutputBlockNumber() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	nextOutputIndex := uint64(len(l.outputsLocked(uint64(len(l.headers) - 1))))
+	return l.config.Oracle.StartingBlockNumber + (nextOutputIndex+1)*l.config.Oracle.SubmissionInterval
+}
+
+// DeleteOutputs deletes the output with the given index and all the later outputs in a new L1 block.
+func (l *L1) DeleteOutputs(outputIndex uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	nextOutputIndex := uint64(len(l.outputsLocked(uint64(len(l.headers) - 1))))
+	if outputIndex >= nextOutputIndex {
+		panic(fmt.Sprintf("output with index %d is not proposed", outputIndex))
+	}
+	l.events = append(l.events, &oracleEvent{
+		l1BlockNumber:       l.mineLocked(),
+		prevNextOutputIndex: nextOutputIndex,
+		newNextOutputIndex:  outputIndex,
+	})
+}
+
+// mineLocked appends an empty block to the chain and returns its height, the caller must hold the mutex.
+func (l *L1) mineLocked() uint64 {
+	number := uint64(len(l.headers))
+	l.headers = append(l.headers, l.newHeader(number, l.headers[number-1].Hash()))
+	return number
+}
+
+// newHeader returns the header of the block with the given height and parent hash.
+func (l *L1) newHeader(number uint64, parentHash common.Hash) *types.Header {
+	return &types.Header{
+		ParentHash: parentHash,
+		Difficulty: big.NewInt(0),
+		Number:     new(big.Int).SetUint64(number),
+		Time:       l.config.GenesisTime + number*l.config.BlockTime,
+	}
+}
+
+// outputsLocked returns the outputs of the oracle at the given L1 block, the caller must hold the mutex.
+func (l *L1) outputsLocked(l1BlockNumber uint64) []*bindings.TypesOutputProposal {
+	var outputs []*bindings.TypesOutputProposal
+	for _, event := range l.events {
+		if event.l1BlockNumber > l1BlockNumber {
+			break
+		}
+		if event.proposal != nil {
+			outputs = append(outputs, event.proposal)
+		} else {
+			outputs = outputs[:event.newNextOutputIndex]
+		}
+	}
+	return outputs
+}
+
+// resolveBlockLocked returns the height of the block with the given number or tag, the caller must hold the mutex.
+func (l *L1) resolveBlockLocked(number rpc.BlockNumber) (uint64, error) {
+	head := uint64(len(l.headers) - 1)
+	if number < 0 {
+		return head, nil
+	}
+	if uint64(number) > head {
+		return 0, fmt.Errorf("header for block %d not found", number)
+	}
+	return uint64(number), nil
+}
+
+// call executes the call of the oracle contract with the given calldata at the given L1 block.
+func (l *L1) call(data []byte, l1BlockNumber uint64) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errExecutionReverted
+	}
+	method, err := l.abi.MethodById(data[:4])
+	if err != nil {
+		return nil, errExecutionReverted
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errExecutionReverted
+	}
+
+	oracle := l.config.Oracle
+	outputs := l.outputsLocked(l1BlockNumber)
+	var result interface{}
+	switch method.RawName {
+	case "nextOutputIndex":
+		result = big.NewInt(int64(len(outputs)))
+	case "latestOutputIndex":
+		if len(outputs) == 0 {
+			return nil, errExecutionReverted
+		}
+		result = big.NewInt(int64(len(outputs) - 1))
+	case "latestBlockNumber":
+		if len(outputs) == 0 {
+			result = new(big.Int).SetUint64(oracle.StartingBlockNumber)
+		} else {
+			result = outputs[len(outputs)-1].L2BlockNumber
+		}
+	case "nextBlockNumber":
+		result = new(big.Int).SetUint64(oracle.StartingBlockNumber + uint64(len(outputs)+1)*oracle.SubmissionInterval)
+	case "getL2Output":
+		index := args[0].(*big.Int)
+		if !index.IsUint64() || index.Uint64() >= uint64(len(outputs)) {
+			return nil, errExecutionReverted
+		}
+		result = *outputs[index.Uint64()]
+	case "computeL2Timestamp":
+		l2BlockNumber := args[0].(*big.Int)
+		timestamp := new(big.Int).Sub(l2BlockNumber, new(big.Int).SetUint64(oracle.StartingBlockNumber))
+		timestamp.Mul(timestamp, new(big.Int).SetUint64(oracle.L2BlockTime))
+		result = timestamp.Add(timestamp, new(big.Int).SetUint64(oracle.StartingTimestamp))
+	case "startingBlockNumber":
+		result = new(big.Int).SetUint64(oracle.StartingBlockNumber)
+	case "startingTimestamp":
+		result = new(big.Int).SetUint64(oracle.StartingTimestamp)
+	case "SUBMISSION_INTERVAL", "submissionInterval":
+		result = new(big.Int).SetUint64(oracle.SubmissionInterval)
+	case "L2_BLOCK_TIME", "l2BlockTime":
+		result = new(big.Int).SetUint64(oracle.L2BlockTime)
+	case "FINALIZATION_PERIOD_SECONDS", "finalizationPeriodSeconds":
+		result = new(big.Int).SetUint64(oracle.FinalizationPeriodSeconds)
+	case "PROPOSER", "proposer":
+		result = oracle.Proposer
+	case "CHALLENGER", "challenger":
+		result = oracle.Challenger
+	default:
+		return nil, errExecutionReverted
+	}
+	return method.Outputs.Pack(result)
+}
+
+// logsLocked returns the logs of the oracle events included in the blocks with height from fromBlock to toBlock, both inclusive, the caller must hold the mutex.
+func (l *L1) logsLocked(fromBlock uint64, toBlock uint64) ([]*types.Log, error) {
+	logs := []*types.Log{}
+	for i, event := range l.events {
+		if event.l1BlockNumber < fromBlock || event.l1BlockNumber > toBlock {
+			continue
+		}
+
+		log := &types.Log{
+			Address:     l.config.Oracle.Address,
+			BlockNumber: event.l1BlockNumber,
+			BlockHash:   l.headers[event.l1BlockNumber].Hash(),
+			TxHash:      crypto.Keccak256Hash(l.config.Oracle.Address.Bytes(), big.NewInt(int64(i)).Bytes()),
+			Index:       uint(i),
+		}
+		if event.proposal != nil {
+			data, err := l.abi.Events["OutputProposed"].Inputs.NonIndexed().Pack(event.proposal.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			log.Topics = []common.Hash{
+				l.abi.Events["OutputProposed"].ID,
+				event.proposal.OutputRoot,
+				common.BigToHash(new(big.Int).SetUint64(event.outputIndex)),
+				common.BigToHash(event.proposal.L2BlockNumber),
+			}
+			log.Data = data
+		} else {
+			log.Topics = []common.Hash{
+				l.abi.Events["OutputsDeleted"].ID,
+				common.BigToHash(new(big.Int).SetUint64(event.prevNextOutputIndex)),
+				common.BigToHash(new(big.Int).SetUint64(event.newNextOutputIndex)),
+			}
+			log.Data = []byte{}
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// callArgs holds the fields of the `eth_call` transaction object used by the fake oracle.
+type callArgs struct {
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// filterQuery holds the fields of the `eth_getLogs` filter object.
+type filterQuery struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+// matches returns true when the log matches the addresses and topics of the filter.
+func (q *filterQuery) matches(log *types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, address := range q.Addresses {
+			found = found || address == log.Address
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for i, topics := range q.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range topics {
+			found = found || topic == log.Topics[i]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// l1Service implements the `eth` namespace of the fake L1 node.
+type l1Service struct {
+	l1 *L1
+}
+
+func (s *l1Service) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(s.l1.config.ChainID)
+}
+
+func (s *l1Service) BlockNumber() hexutil.Uint64 {
+	s.l1.mutex.RLock()
+	defer s.l1.mutex.RUnlock()
+
+	return hexutil.Uint64(len(s.l1.headers) - 1)
+}
+
+func (s *l1Service) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	s.l1.mutex.RLock()
+	defer s.l1.mutex.RUnlock()
+
+	height, err := s.l1.resolveBlockLocked(number)
+	if err != nil {
+		return nil, nil
+	}
+	return s.l1.headers[height], nil
+}
+
+func (s *l1Service) GetBlockByHash(hash common.Hash, fullTx bool) (*types.Header, error) {
+	s.l1.mutex.RLock()
+	defer s.l1.mutex.RUnlock()
+
+	for _, header := range s.l1.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *l1Service) Call(args callArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	s.l1.mutex.RLock()
+	defer s.l1.mutex.RUnlock()
+
+	l1BlockNumber, err := s.resolveBlockNumberOrHashLocked(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if args.To == nil || *args.To != s.l1.config.Oracle.Address {
+		return hexutil.Bytes{}, nil
+	}
+
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	return s.l1.call(data, l1BlockNumber)
+}
+
+func (s *l1Service) GetCode(address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if address != s.l1.config.Oracle.Address {
+		return hexutil.Bytes{}, nil
+	}
+	return hexutil.Bytes{0x1}, nil
+}
+
+func (s *l1Service) GetStorageAt(address common.Address, slot common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if address == s.l1.config.Oracle.Address && slot == implementationSlot {
+		return common.BytesToHash(s.l1.config.Oracle.Implementation.Bytes()).Bytes(), nil
+	}
+	return common.Hash{}.Bytes(), nil
+}
+
+func (s *l1Service) GetLogs(query filterQuery) ([]*types.Log, error) {
+	s.l1.mutex.RLock()
+	defer s.l1.mutex.RUnlock()
+
+	fromBlock, toBlock := uint64(0), uint64(len(s.l1.headers)-1)
+	if query.BlockHash != nil {
+		height, err := s.resolveBlockNumberOrHashLocked(rpc.BlockNumberOrHashWithHash(*query.BlockHash, false))
+		if err != nil {
+			return nil, err
+		}
+		fromBlock, toBlock = height, height
+	} else {
+		if query.FromBlock != nil {
+			height, err := s.l1.resolveBlockLocked(*query.FromBlock)
+			if err != nil {
+				return nil, err
+			}
+			fromBlock = height
+		}
+		if query.ToBlock != nil {
+			height, err := s.l1.resolveBlockLocked(*query.ToBlock)
+			if err != nil {
+				return nil, err
+			}
+			toBlock = height
+		}
+	}
+
+	logs, err := s.l1.logsLocked(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	matching := []*types.Log{}
+	for _, log := range logs {
+		if query.matches(log) {
+			matching = append(matching, log)
+		}
+	}
+	return matching, nil
+}
+
+// resolveBlockNumberOrHashLocked returns the height of the block with the given number, tag or hash, the caller must hold the mutex.
+func (s *l1Service) resolveBlockNumberOrHashLocked(blockNrOrHash rpc.BlockNumberOrHash) (uint64, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		for i, header := range s.l1.headers {
+			if header.Hash() == hash {
+				return uint64(i), nil
+			}
+		}
+		return 0, fmt.Errorf("header for hash %s not found", hash)
+	}
+
+	number, _ := blockNrOrHash.Number()
+	return s.l1.resolveBlockLocked(number)
+}
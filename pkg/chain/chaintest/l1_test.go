@@ -0,0 +1,117 @@
+package chaintest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var testOracleConfig = OracleConfig{
+	Address:                   common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	Implementation:            common.HexToAddress("0x2222222222222222222222222222222222222222"),
+	StartingBlockNumber:       10,
+	StartingTimestamp:         1000,
+	SubmissionInterval:        20,
+	L2BlockTime:               2,
+	FinalizationPeriodSeconds: 604800,
+	Proposer:                  common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	Challenger:                common.HexToAddress("0x4444444444444444444444444444444444444444"),
+}
+
+func newTestOracleAccessor(t *testing.T, l1 *L1) *chain.OracleAccessor {
+	oracle, err := chain.NewOracleAccessor(context.Background(), &chain.ConfigOptions{
+		L1RPCEndpoint:                 l1.URL(),
+		ChainID:                       901,
+		L2OutputOracleContractAddress: testOracleConfig.Address.Hex(),
+	})
+	require.NoError(t, err)
+	return oracle
+}
+
+func TestL1_Oracle(t *testing.T) {
+	l1 := NewL1(L1Config{ChainID: 900, GenesisTime: 2000, BlockTime: 12, Oracle: testOracleConfig})
+	defer l1.Close()
+	oracle := newTestOracleAccessor(t, l1)
+
+	outputRoot := common.HexToHash("0x01")
+	require.Equal(t, uint64(30), l1.NextOutputBlockNumber())
+	require.Equal(t, uint64(0), l1.ProposeOutput(outputRoot, 30))
+	require.Equal(t, uint64(50), l1.NextOutputBlockNumber())
+	require.Equal(t, uint64(1), l1.ProposeOutput(common.HexToHash("0x02"), 50))
+
+	nextOutputIndex, err := oracle.GetNextOutputIndex()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), nextOutputIndex)
+
+	output, err := oracle.GetL2Output(big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, chain.L2Output{OutputRoot: outputRoot.Hex(), L1Timestamp: 2012, L2BlockNumber: 30, L2OutputIndex: 0}, output)
+
+	_, err = oracle.GetL2Output(big.NewInt(2))
+	require.Error(t, err)
+
+	timestamp, err := oracle.ComputeL2Timestamp(30)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1040), timestamp)
+
+	schedule, err := oracle.GetOutputSchedule()
+	require.NoError(t, err)
+	require.Equal(t, chain.OutputSchedule{StartingBlockNumber: 10, SubmissionInterval: 20}, schedule)
+
+	parameters, err := oracle.GetOracleParameters(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, chain.OracleParameters{
+		Implementation:            testOracleConfig.Implementation,
+		FinalizationPeriodSeconds: testOracleConfig.FinalizationPeriodSeconds,
+		Challenger:                testOracleConfig.Challenger,
+		Proposer:                  testOracleConfig.Proposer,
+	}, parameters)
+}
+
+func TestL1_DeleteOutputs(t *testing.T) {
+	l1 := NewL1(L1Config{ChainID: 900, GenesisTime: 2000, BlockTime: 12, Oracle: testOracleConfig})
+	defer l1.Close()
+	oracle := newTestOracleAccessor(t, l1)
+
+	l1.ProposeOutput(common.HexToHash("0x01"), 10)
+	l1.ProposeOutput(common.HexToHash("0x02"), 30)
+	l1.ProposeOutput(common.HexToHash("0x03"), 50)
+	header, err := oracle.PinL1Block(context.Background())
+	require.NoError(t, err)
+	l1.DeleteOutputs(1)
+
+	// The reads pinned before the deletion still see the deleted outputs
+	nextOutputIndex, err := oracle.GetNextOutputIndex()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), nextOutputIndex)
+
+	_, err = oracle.PinL1Block(context.Background())
+	require.NoError(t, err)
+	nextOutputIndex, err = oracle.GetNextOutputIndex()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), nextOutputIndex)
+
+	deletions, err := oracle.GetOutputsDeleted(context.Background(), header.Number.Uint64(), header.Number.Uint64()+1)
+	require.NoError(t, err)
+	require.Equal(t, []chain.OutputsDeleted{{PrevNextOutputIndex: 3, NewNextOutputIndex: 1, L1BlockNumber: header.Number.Uint64() + 1}}, deletions)
+}
+
+func TestNode_FailMethod(t *testing.T) {
+	l1 := NewL1(L1Config{ChainID: 900, GenesisTime: 2000, BlockTime: 12, Oracle: testOracleConfig})
+	defer l1.Close()
+	oracle := newTestOracleAccessor(t, l1)
+
+	l1.FailMethod("eth_call", errors.New("rate limited"))
+	_, err := oracle.GetNextOutputIndex()
+	require.ErrorContains(t, err, "rate limited")
+
+	l1.RecoverMethod("eth_call")
+	_, err = oracle.GetNextOutputIndex()
+	require.NoError(t, err)
+	require.Equal(t, 2, l1.Calls("eth_call"))
+}
@@ -0,0 +1,244 @@
+package chaintest
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// L2Config holds the parameters of a fake L2 chain.
+type L2Config struct {
+	ChainID     uint64
+	GenesisTime uint64
+	BlockTime   uint64
+}
+
+// L2 is a fake L2 node. Every block commits to a state holding only the L2ToL1MessagePasser account, whose storage root is derived from the block height.
+// Two L2 nodes created with the same config serve the same chain.
+type L2 struct {
+	*Node
+	config L2Config
+	mutex  sync.RWMutex
+	blocks []*l2Block
+	head   uint64
+	salts  map[uint64][]byte
+}
+
+// l2Block is a block of the fake L2 chain along with the account proof of the L2ToL1MessagePasser account at that block.
+type l2Block struct {
+	header *types.Header
+	proof  *chain.ProofResponse
+}
+
+// proofNodes collects the trie nodes of an account proof.
+type proofNodes []hexutil.Bytes
+
+func (n *proofNodes) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
+func (n *proofNodes) Delete(key []byte) error {
+	return errors.New("not supported")
+}
+
+// NewL2 starts a fake L2 node with the genesis block only.
+func NewL2(config L2Config) *L2 {
+	l2 := &L2{
+		config: config,
+		salts:  make(map[uint64][]byte),
+	}
+	l2.blocks = []*l2Block{l2.newBlock(0, common.Hash{})}
+	l2.Node = newNode(&l2Service{l2: l2})
+	return l2
+}
+
+// Mine extends the chain up to the given height and serves it as the latest block.
+func (l *L2) Mine(number uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.extendLocked(number)
+	if number > l.head {
+		l.head = number
+	}
+}
+
+// SetHead serves the block with the given height as the latest block, e.g. to simulate a node lagging behind. The later blocks are not served.
+func (l *L2) SetHead(number uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.extendLocked(number)
+	l.head = number
+}
+
+// Head returns the height of the latest block served.
+func (l *L2) Head() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.head
+}
+
+// Diverge changes the state of the L2ToL1MessagePasser account from the block with the given height onwards, so that the node serves a different chain than the other nodes created with the same config.
+// The given salt identifies the diverged chain, nodes diverged with the same salt serve the same chain.
+func (l *L2) Diverge(number uint64, salt string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.extendLocked(number)
+	l.salts[number] = []byte(salt)
+	for i := number; i < uint64(len(l.blocks)); i++ {
+		l.blocks[i] = l.newBlock(i, l.parentHashLocked(i))
+	}
+}
+
+// OutputRoot returns the output root of the block with the given height, as computed by the fault detector.
+func (l *L2) OutputRoot(number uint64) common.Hash {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.extendLocked(number)
+	block := l.blocks[number]
+	return common.HexToHash(encoding.ComputeL2OutputRoot(block.header.Root, block.proof.StorageHash, block.header.Hash()))
+}
+
+// extendLocked generates the blocks up to the given height, the caller must hold the mutex.
+func (l *L2) extendLocked(number uint64) {
+	for i := uint64(len(l.blocks)); i <= number; i++ {
+		l.blocks = append(l.blocks, l.newBlock(i, l.parentHashLocked(i)))
+	}
+}
+
+// parentHashLocked returns the hash of the parent of the block with the given height, the caller must hold the mutex.
+func (l *L2) parentHashLocked(number uint64) common.Hash {
+	if number == 0 {
+		return common.Hash{}
+	}
+	return l.blocks[number-1].header.Hash()
+}
+
+// saltLocked returns the salt of the chain at the given height, the caller must hold the mutex.
+func (l *L2) saltLocked(number uint64) []byte {
+	var salt []byte
+	var divergedAt uint64
+	found := false
+	for height, s := range l.salts {
+		if height <= number && (!found || height > divergedAt) {
+			salt, divergedAt, found = s, height, true
+		}
+	}
+	return salt
+}
+
+// newBlock returns the block with the given height and parent hash.
+func (l *L2) newBlock(number uint64, parentHash common.Hash) *l2Block {
+	height := make([]byte, 8)
+	binary.BigEndian.PutUint64(height, number)
+	storageRoot := crypto.Keccak256Hash(new(big.Int).SetUint64(l.config.ChainID).Bytes(), height, l.saltLocked(number))
+
+	address := common.HexToAddress(chain.L2BedrockMessagePasserAddress)
+	account := types.StateAccount{Nonce: 1, Balance: big.NewInt(0), Root: storageRoot, CodeHash: crypto.Keccak256(nil)}
+	value, err := rlp.EncodeToBytes(&account)
+	if err != nil {
+		panic(err)
+	}
+
+	state := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	state.MustUpdate(crypto.Keccak256(address.Bytes()), value)
+	var nodes proofNodes
+	if err := state.Prove(crypto.Keccak256(address.Bytes()), &nodes); err != nil {
+		panic(err)
+	}
+
+	return &l2Block{
+		header: &types.Header{
+			ParentHash: parentHash,
+			Root:       state.Hash(),
+			Difficulty: big.NewInt(0),
+			Number:     new(big.Int).SetUint64(number),
+			Time:       l.config.GenesisTime + number*l.config.BlockTime,
+		},
+		proof: &chain.ProofResponse{
+			Address:      address,
+			AccountProof: nodes,
+			Balance:      (*hexutil.Big)(big.NewInt(0)),
+			CodeHash:     common.BytesToHash(account.CodeHash),
+			Nonce:        hexutil.Uint64(account.Nonce),
+			StorageHash:  storageRoot,
+		},
+	}
+}
+
+// servedBlock returns the block with the given number or tag, or nil when not served.
+func (l *L2) servedBlock(number rpc.BlockNumber) *l2Block {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	height := l.head
+	if number >= 0 {
+		height = uint64(number.Int64())
+	}
+	if height > l.head {
+		return nil
+	}
+	return l.blocks[height]
+}
+
+// l2Service implements the `eth` namespace of the fake L2 node.
+type l2Service struct {
+	l2 *L2
+}
+
+func (s *l2Service) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(s.l2.config.ChainID)
+}
+
+func (s *l2Service) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.l2.Head())
+}
+
+func (s *l2Service) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	block := s.l2.servedBlock(number)
+	if block == nil {
+		return nil, nil
+	}
+	return block.header, nil
+}
+
+func (s *l2Service) GetBlockByHash(hash common.Hash, fullTx bool) (*types.Header, error) {
+	s.l2.mutex.RLock()
+	defer s.l2.mutex.RUnlock()
+
+	for _, block := range s.l2.blocks[:s.l2.head+1] {
+		if block.header.Hash() == hash {
+			return block.header, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *l2Service) GetProof(address common.Address, storageKeys []string, number rpc.BlockNumber) (*chain.ProofResponse, error) {
+	block := s.l2.servedBlock(number)
+	if block == nil {
+		return nil, fmt.Errorf("header for block %d not found", number)
+	}
+	if address != block.proof.Address {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	return block.proof, nil
+}
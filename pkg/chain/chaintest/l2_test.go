@@ -0,0 +1,81 @@
+package chaintest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestL2Client(t *testing.T, l2 *L2) *chain.ChainAPIClient {
+	logger, _ := log.NewDefaultProductionLogger()
+	client, err := chain.GetAPIClient(context.Background(), l2.URL(), logger)
+	require.NoError(t, err)
+	return client
+}
+
+func TestL2_OutputRoot(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewL2(L2Config{ChainID: 901, GenesisTime: 1000, BlockTime: 2})
+	defer l2.Close()
+	l2.Mine(100)
+	client := newTestL2Client(t, l2)
+
+	chainID, err := client.GetChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(901), chainID)
+
+	latestBlockNumber, err := client.GetLatestBlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), latestBlockNumber)
+
+	header, err := client.GetBlockHeaderByNumber(ctx, big.NewInt(50))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1100), header.Time)
+	headerByHash, err := client.GetBlockHeaderByHash(ctx, header.Hash())
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), headerByHash.Hash())
+
+	proof, err := client.GetProof(ctx, big.NewInt(50), common.HexToAddress(chain.L2BedrockMessagePasserAddress))
+	require.NoError(t, err)
+	require.Equal(t, l2.OutputRoot(50).Hex(), encoding.ComputeL2OutputRoot(header.Root, proof.StorageHash, header.Hash()))
+
+	// Nodes created with the same config serve the same chain
+	other := NewL2(L2Config{ChainID: 901, GenesisTime: 1000, BlockTime: 2})
+	defer other.Close()
+	require.Equal(t, l2.OutputRoot(50), other.OutputRoot(50))
+}
+
+func TestL2_SetHead(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewL2(L2Config{ChainID: 901, GenesisTime: 1000, BlockTime: 2})
+	defer l2.Close()
+	l2.Mine(100)
+	l2.SetHead(40)
+	client := newTestL2Client(t, l2)
+
+	latestBlockNumber, err := client.GetLatestBlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), latestBlockNumber)
+
+	_, err = client.GetBlockHeaderByNumber(ctx, big.NewInt(50))
+	require.Error(t, err)
+}
+
+func TestL2_Diverge(t *testing.T) {
+	l2 := NewL2(L2Config{ChainID: 901, GenesisTime: 1000, BlockTime: 2})
+	defer l2.Close()
+	other := NewL2(L2Config{ChainID: 901, GenesisTime: 1000, BlockTime: 2})
+	defer other.Close()
+
+	outputRoot := l2.OutputRoot(60)
+	other.Diverge(50, "fault")
+	require.Equal(t, l2.OutputRoot(49), other.OutputRoot(49))
+	require.NotEqual(t, l2.OutputRoot(50), other.OutputRoot(50))
+	require.NotEqual(t, outputRoot, other.OutputRoot(60))
+}
@@ -0,0 +1,129 @@
+// Package chaintest implements in-process fake L1 and L2 nodes serving the JSON-RPC methods used by the fault detector, to run it end to end in tests.
+// The nodes are scriptable: outputs can be proposed to and deleted from the fake oracle, the L2 node can lag behind or serve a diverged state and any JSON-RPC method can be made to fail.
+package chaintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// errorCodeServer is the JSON-RPC error code returned for the injected failures.
+const errorCodeServer = -32000
+
+// Node is a JSON-RPC server over HTTP, serving the `eth` namespace of a fake chain.
+type Node struct {
+	server   *httptest.Server
+	rpc      *rpc.Server
+	mutex    sync.RWMutex
+	failures map[string]error
+	calls    map[string]int
+}
+
+// rpcRequest holds the fields of a JSON-RPC request required to inject the failures.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   rpcError        `json:"error"`
+}
+
+// newNode starts a JSON-RPC server serving the methods of the given service in the `eth` namespace.
+func newNode(service interface{}) *Node {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("eth", service); err != nil {
+		panic(err)
+	}
+
+	node := &Node{
+		rpc:      rpcServer,
+		failures: make(map[string]error),
+		calls:    make(map[string]int),
+	}
+	node.server = httptest.NewServer(node)
+	return node
+}
+
+// URL returns the HTTP endpoint of the node.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Close stops the node.
+func (n *Node) Close() {
+	n.server.Close()
+	n.rpc.Stop()
+}
+
+// FailMethod makes every subsequent call of the given JSON-RPC method, e.g. `eth_getProof`, fail with the given error.
+func (n *Node) FailMethod(method string, err error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.failures[method] = err
+}
+
+// RecoverMethod serves the given JSON-RPC method again after a failure injected with FailMethod.
+func (n *Node) RecoverMethod(method string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.failures, method)
+}
+
+// Calls returns the number of calls of the given JSON-RPC method received by the node, including the failed ones.
+func (n *Node) Calls(method string) int {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	return n.calls[method]
+}
+
+// ServeHTTP serves a JSON-RPC request, unless a failure is injected for its method.
+// Batch requests are served without injecting the failures.
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var request rpcRequest
+	if err := json.Unmarshal(body, &request); err == nil && request.Method != "" {
+		if err := n.recordCall(request.Method); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(rpcErrorResponse{
+				Version: "2.0",
+				ID:      request.ID,
+				Error:   rpcError{Code: errorCodeServer, Message: err.Error()},
+			})
+			return
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	n.rpc.ServeHTTP(w, r)
+}
+
+// recordCall counts the call of the given method and returns the failure injected for it, if any.
+func (n *Node) recordCall(method string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.calls[method]++
+	return n.failures[method]
+}
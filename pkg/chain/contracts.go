@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -10,27 +11,73 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
 )
 
+// implementationSlot is the EIP-1967 storage slot of the proxy implementation address, i.e. `bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1)`.
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// ErrSubscriptionNotSupported is returned when the L1 provider does not support subscriptions, e.g. for HTTP endpoints.
+var ErrSubscriptionNotSupported = errors.New("subscriptions are not supported by the L1 provider")
+
 // L2Output is the output of GetL2Output.
+// Unmonitored is true for the outputs that are not verified, i.e. the dispute games of a type other than the configured one.
 type L2Output struct {
 	OutputRoot    string
 	L1Timestamp   uint64
 	L2BlockNumber uint64
 	L2OutputIndex uint64
+	Unmonitored   bool
+}
+
+// OutputsDeleted holds the range of the outputs deleted from the oracle, as emitted by the `OutputsDeleted` event.
+type OutputsDeleted struct {
+	PrevNextOutputIndex uint64
+	NewNextOutputIndex  uint64
+	L1BlockNumber       uint64
+}
+
+// OutputProposal is the L1 transaction that proposed an output to the oracle contract.
+type OutputProposal struct {
+	L2OutputIndex uint64
+	OutputRoot    string
+	L1BlockNumber uint64
+	L1TxHash      common.Hash
+	Sender        common.Address
+}
+
+// OracleParameters holds the oracle proxy implementation and the oracle parameters critical to the fault detection.
+type OracleParameters struct {
+	Implementation            common.Address
+	FinalizationPeriodSeconds uint64
+	Challenger                common.Address
+	Proposer                  common.Address
+}
+
+// OutputSchedule holds the oracle parameters that determine the L2 block number of every output.
+type OutputSchedule struct {
+	StartingBlockNumber uint64
+	SubmissionInterval  uint64
 }
 
 // OracleAccessor binds oracle contract to an instance for querying data.
 type OracleAccessor struct {
+	*blockPinner
+	client           *ethclient.Client
+	contractAddress  common.Address
 	contractInstance *bindings.L2OutputOracle
 }
 
 // ConfigOptions are the options required to interact with the oracle contract.
 type ConfigOptions struct {
-	L1RPCEndpoint                 string
-	ChainID                       uint64
-	L2OutputOracleContractAddress string
+	L1RPCEndpoint                     string
+	ChainID                           uint64
+	L2OutputOracleContractAddress     string
+	DisputeGameFactoryContractAddress string
+	DisputeGameType                   uint8
+	L1ReadDepth                       string
 }
 
 func getL1OracleContractAddressByChainID(chainID uint64) (string, bool) {
@@ -60,25 +107,34 @@ func NewOracleAccessor(ctx context.Context, opts *ConfigOptions) (*OracleAccesso
 		oracleContractAddress = opts.L2OutputOracleContractAddress
 	}
 
-	oracleContractInstance, err := bindings.NewL2OutputOracle(common.HexToAddress(oracleContractAddress), client)
+	contractAddress := common.HexToAddress(oracleContractAddress)
+	oracleContractInstance, err := bindings.NewL2OutputOracle(contractAddress, client)
+
+	if err != nil {
+		return nil, err
+	}
 
+	pinner, err := newBlockPinner(client, opts.L1ReadDepth)
 	if err != nil {
 		return nil, err
 	}
 
 	return &OracleAccessor{
+		blockPinner:      pinner,
+		client:           client,
+		contractAddress:  contractAddress,
 		contractInstance: oracleContractInstance,
 	}, nil
 }
 
 // GetNextOutputIndex returns index of next output to be proposed.
 func (oc *OracleAccessor) GetNextOutputIndex() (*big.Int, error) {
-	return oc.contractInstance.NextOutputIndex(&bind.CallOpts{})
+	return oc.contractInstance.NextOutputIndex(oc.callOpts())
 }
 
 // GetL2Output returns L2 output at given index.
 func (oc *OracleAccessor) GetL2Output(index *big.Int) (L2Output, error) {
-	l2Output, err := oc.contractInstance.GetL2Output(&bind.CallOpts{}, index)
+	l2Output, err := oc.contractInstance.GetL2Output(oc.callOpts(), index)
 	if err != nil {
 		return L2Output{}, err
 	}
@@ -93,5 +149,270 @@ func (oc *OracleAccessor) GetL2Output(index *big.Int) (L2Output, error) {
 
 // FinalizationPeriodSeconds returns output finalization time in seconds.
 func (oc *OracleAccessor) FinalizationPeriodSeconds() (*big.Int, error) {
-	return oc.contractInstance.FINALIZATIONPERIODSECONDS(&bind.CallOpts{})
+	return oc.contractInstance.FINALIZATIONPERIODSECONDS(oc.callOpts())
+}
+
+// SubmissionIntervalSeconds returns the expected interval between the output proposals in seconds, i.e. `SUBMISSION_INTERVAL` in L2 blocks times `L2_BLOCK_TIME`.
+func (oc *OracleAccessor) SubmissionIntervalSeconds() (*big.Int, error) {
+	submissionInterval, err := oc.contractInstance.SUBMISSIONINTERVAL(oc.callOpts())
+	if err != nil {
+		return nil, err
+	}
+
+	l2BlockTime, err := oc.contractInstance.L2BLOCKTIME(oc.callOpts())
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Mul(submissionInterval, l2BlockTime), nil
+}
+
+// ComputeL2Timestamp returns the timestamp the L2 block with the given number is expected to have, as computed by the oracle contract.
+func (oc *OracleAccessor) ComputeL2Timestamp(l2BlockNumber uint64) (uint64, error) {
+	timestamp, err := oc.contractInstance.ComputeL2Timestamp(oc.callOpts(), new(big.Int).SetUint64(l2BlockNumber))
+	if err != nil {
+		return 0, err
+	}
+
+	return encoding.MustConvertBigIntToUint64(timestamp), nil
+}
+
+// GetOutputSchedule returns the L2 block number of the first output and the `SUBMISSION_INTERVAL` in L2 blocks between the outputs.
+func (oc *OracleAccessor) GetOutputSchedule() (OutputSchedule, error) {
+	startingBlockNumber, err := oc.contractInstance.StartingBlockNumber(oc.callOpts())
+	if err != nil {
+		return OutputSchedule{}, err
+	}
+
+	submissionInterval, err := oc.contractInstance.SUBMISSIONINTERVAL(oc.callOpts())
+	if err != nil {
+		return OutputSchedule{}, err
+	}
+
+	return OutputSchedule{
+		StartingBlockNumber: encoding.MustConvertBigIntToUint64(startingBlockNumber),
+		SubmissionInterval:  encoding.MustConvertBigIntToUint64(submissionInterval),
+	}, nil
+}
+
+// Proposer returns the address allowed to propose the outputs to the oracle contract.
+func (oc *OracleAccessor) Proposer() (common.Address, error) {
+	return oc.contractInstance.PROPOSER(oc.callOpts())
+}
+
+// GetOracleParameters returns the implementation address stored in the EIP-1967 slot of the oracle proxy and the oracle parameters.
+func (oc *OracleAccessor) GetOracleParameters(ctx context.Context) (OracleParameters, error) {
+	callOpts := oc.callOpts()
+	callOpts.Context = ctx
+
+	implementation, err := oc.client.StorageAt(ctx, oc.contractAddress, implementationSlot, callOpts.BlockNumber)
+	if err != nil {
+		return OracleParameters{}, err
+	}
+
+	finalizationPeriodSeconds, err := oc.contractInstance.FINALIZATIONPERIODSECONDS(callOpts)
+	if err != nil {
+		return OracleParameters{}, err
+	}
+
+	challenger, err := oc.contractInstance.CHALLENGER(callOpts)
+	if err != nil {
+		return OracleParameters{}, err
+	}
+
+	proposer, err := oc.contractInstance.PROPOSER(callOpts)
+	if err != nil {
+		return OracleParameters{}, err
+	}
+
+	return OracleParameters{
+		Implementation:            common.BytesToAddress(implementation),
+		FinalizationPeriodSeconds: encoding.MustConvertBigIntToUint64(finalizationPeriodSeconds),
+		Challenger:                challenger,
+		Proposer:                  proposer,
+	}, nil
+}
+
+// GetOutputProposal returns the L1 transaction that emitted the `OutputProposed` event of the output with the given index, output root and L1 timestamp.
+// The events are only filtered from the L1 block with the L1 timestamp of the output, i.e. the block of its latest proposal, onwards.
+// When the output was proposed more than once, e.g. after the outputs were deleted, the latest proposal is returned.
+func (oc *OracleAccessor) GetOutputProposal(ctx context.Context, index uint64, outputRoot string, l1Timestamp uint64) (*OutputProposal, error) {
+	var end *uint64
+	if blockNumber := oc.callOpts().BlockNumber; blockNumber != nil {
+		endBlock := blockNumber.Uint64()
+		end = &endBlock
+	}
+	start, err := findBlockByTimestamp(ctx, oc.client.HeaderByNumber, l1Timestamp, end)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := oc.contractInstance.FilterOutputProposed(&bind.FilterOpts{Start: start, End: end, Context: ctx}, [][32]byte{common.HexToHash(outputRoot)}, []*big.Int{new(big.Int).SetUint64(index)}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var proposed *bindings.L2OutputOracleOutputProposed
+	for it.Next() {
+		proposed = it.Event
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if proposed == nil {
+		return nil, fmt.Errorf("no OutputProposed event found for output index %d", index)
+	}
+
+	tx, err := oc.client.TransactionInBlock(ctx, proposed.Raw.BlockHash, proposed.Raw.TxIndex)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := oc.client.TransactionSender(ctx, tx, proposed.Raw.BlockHash, proposed.Raw.TxIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputProposal{
+		L2OutputIndex: index,
+		OutputRoot:    outputRoot,
+		L1BlockNumber: proposed.Raw.BlockNumber,
+		L1TxHash:      proposed.Raw.TxHash,
+		Sender:        sender,
+	}, nil
+}
+
+// headerByNumberFunc returns the header of the block with the given height, or of the latest block when nil.
+type headerByNumberFunc func(ctx context.Context, number *big.Int) (*types.Header, error)
+
+// findBlockByTimestamp returns the height of the first block with a timestamp not before the given timestamp, searched up to the end block, or the latest block when nil.
+// The height is interpolated from the timestamps of the blocks, produced at a regular interval, alternating with a bisection to bound the number of headers fetched.
+func findBlockByTimestamp(ctx context.Context, headerByNumber headerByNumberFunc, timestamp uint64, end *uint64) (uint64, error) {
+	var endNumber *big.Int
+	if end != nil {
+		endNumber = new(big.Int).SetUint64(*end)
+	}
+	hi, err := headerByNumber(ctx, endNumber)
+	if err != nil {
+		return 0, err
+	}
+	if hi.Time < timestamp {
+		return 0, fmt.Errorf("no L1 block found with timestamp %d, block %d has timestamp %d", timestamp, hi.Number.Uint64(), hi.Time)
+	}
+	lo, err := headerByNumber(ctx, big.NewInt(0))
+	if err != nil {
+		return 0, err
+	}
+	if lo.Time >= timestamp {
+		return 0, nil
+	}
+
+	// Keep the first block with the timestamp between the lower block, excluded, and the upper block, included
+	for i := 0; hi.Number.Uint64()-lo.Number.Uint64() > 1; i++ {
+		loNumber, hiNumber := lo.Number.Uint64(), hi.Number.Uint64()
+		guess := loNumber + (hiNumber-loNumber)/2
+		if i%2 == 0 {
+			guess = loNumber + (timestamp-lo.Time)*(hiNumber-loNumber)/(hi.Time-lo.Time)
+		}
+		guess = max(loNumber+1, min(guess, hiNumber-1))
+
+		header, err := headerByNumber(ctx, new(big.Int).SetUint64(guess))
+		if err != nil {
+			return 0, err
+		}
+		if header.Time >= timestamp {
+			hi = header
+		} else {
+			lo = header
+		}
+	}
+
+	return hi.Number.Uint64(), nil
+}
+
+// SubscribeOutputProposed subscribes to the `OutputProposed` events of the oracle contract and sends every proposed output to the given channel.
+// Subscriptions are only supported when the L1 provider is connected over websocket.
+func (oc *OracleAccessor) SubscribeOutputProposed(ctx context.Context, sink chan<- L2Output) (event.Subscription, error) {
+	if !oc.client.Client().SupportsSubscriptions() {
+		return nil, ErrSubscriptionNotSupported
+	}
+
+	logs := make(chan *bindings.L2OutputOracleOutputProposed)
+	sub, err := oc.contractInstance.WatchOutputProposed(&bind.WatchOpts{Context: ctx}, logs, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return forwardEvents(sub, logs, sink, func(log *bindings.L2OutputOracleOutputProposed) L2Output {
+		return L2Output{
+			OutputRoot:    hexutil.Encode(log.OutputRoot[:]),
+			L1Timestamp:   encoding.MustConvertBigIntToUint64(log.L1Timestamp),
+			L2BlockNumber: encoding.MustConvertBigIntToUint64(log.L2BlockNumber),
+			L2OutputIndex: encoding.MustConvertBigIntToUint64(log.L2OutputIndex),
+		}
+	}), nil
+}
+
+// SubscribeOutputsDeleted subscribes to the `OutputsDeleted` events of the oracle contract and sends every deletion to the given channel.
+// Subscriptions are only supported when the L1 provider is connected over websocket.
+func (oc *OracleAccessor) SubscribeOutputsDeleted(ctx context.Context, sink chan<- OutputsDeleted) (event.Subscription, error) {
+	if !oc.client.Client().SupportsSubscriptions() {
+		return nil, ErrSubscriptionNotSupported
+	}
+
+	logs := make(chan *bindings.L2OutputOracleOutputsDeleted)
+	sub, err := oc.contractInstance.WatchOutputsDeleted(&bind.WatchOpts{Context: ctx}, logs, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return forwardEvents(sub, logs, sink, toOutputsDeleted), nil
+}
+
+// GetOutputsDeleted returns the deletions of outputs from the oracle contract within the given L1 block range, both inclusive.
+func (oc *OracleAccessor) GetOutputsDeleted(ctx context.Context, fromBlock uint64, toBlock uint64) ([]OutputsDeleted, error) {
+	it, err := oc.contractInstance.FilterOutputsDeleted(&bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var deletions []OutputsDeleted
+	for it.Next() {
+		deletions = append(deletions, toOutputsDeleted(it.Event))
+	}
+
+	return deletions, it.Error()
+}
+
+func toOutputsDeleted(log *bindings.L2OutputOracleOutputsDeleted) OutputsDeleted {
+	return OutputsDeleted{
+		PrevNextOutputIndex: encoding.MustConvertBigIntToUint64(log.PrevNextOutputIndex),
+		NewNextOutputIndex:  encoding.MustConvertBigIntToUint64(log.NewNextOutputIndex),
+		L1BlockNumber:       log.Raw.BlockNumber,
+	}
+}
+
+// forwardEvents converts the events received by the given contract event subscription and sends them to the sink.
+// The returned subscription ends when the contract event subscription fails or when unsubscribed.
+func forwardEvents[T any, U any](sub event.Subscription, logs <-chan T, sink chan<- U, convert func(T) U) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				select {
+				case sink <- convert(log):
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	})
 }
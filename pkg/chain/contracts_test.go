@@ -1,9 +1,14 @@
 package chain
 
 import (
+	"context"
+	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetL1OracleContractAddressByChainID(t *testing.T) {
@@ -20,3 +25,103 @@ func TestGetL1OracleContractAddressByChainID(t *testing.T) {
 	assert.Equal(false, isAddressExists)
 	assert.Equal(0, len(contractAddress))
 }
+
+// newHeaderByNumber returns a [headerByNumberFunc] serving the headers of the blocks with the given timestamps, along with the number of headers served.
+func newHeaderByNumber(timestamps []uint64) (headerByNumberFunc, *int) {
+	calls := 0
+	return func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		calls++
+		if number == nil {
+			number = big.NewInt(int64(len(timestamps) - 1))
+		}
+		if number.Uint64() >= uint64(len(timestamps)) {
+			return nil, fmt.Errorf("header for block %d not found", number)
+		}
+		return &types.Header{Number: number, Time: timestamps[number.Uint64()]}, nil
+	}, &calls
+}
+
+func TestFindBlockByTimestamp(t *testing.T) {
+	regular := make([]uint64, 100000)
+	for i := range regular {
+		regular[i] = 1000 + uint64(i)*12
+	}
+	// Blocks produced every 12 seconds, after a first period with blocks produced every second
+	irregular := make([]uint64, 100000)
+	for i := range irregular {
+		irregular[i] = 1000 + uint64(i)
+		if i >= 90000 {
+			irregular[i] = irregular[89999] + uint64(i-89999)*12
+		}
+	}
+	end := uint64(50000)
+
+	tests := []struct {
+		name          string
+		timestamps    []uint64
+		timestamp     uint64
+		end           *uint64
+		expected      uint64
+		expectedErr   bool
+		maxHeaderRead int
+	}{
+		{
+			name:          "should return the block with the given timestamp",
+			timestamps:    regular,
+			timestamp:     1000 + 76543*12,
+			expected:      76543,
+			maxHeaderRead: 5,
+		},
+		{
+			name:          "should return the first block after the given timestamp",
+			timestamps:    regular,
+			timestamp:     1000 + 76543*12 - 5,
+			expected:      76543,
+			maxHeaderRead: 5,
+		},
+		{
+			name:          "should return the block with the given timestamp with irregular block times",
+			timestamps:    irregular,
+			timestamp:     irregular[95000],
+			expected:      95000,
+			maxHeaderRead: 40,
+		},
+		{
+			name:          "should return the genesis block when the given timestamp is before it",
+			timestamps:    regular,
+			timestamp:     500,
+			expected:      0,
+			maxHeaderRead: 2,
+		},
+		{
+			name:          "should search up to the end block",
+			timestamps:    regular,
+			timestamp:     1000 + 1234*12,
+			end:           &end,
+			expected:      1234,
+			maxHeaderRead: 5,
+		},
+		{
+			name:        "should return error when the given timestamp is after the end block",
+			timestamps:  regular,
+			timestamp:   1000 + 76543*12,
+			end:         &end,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headerByNumber, calls := newHeaderByNumber(test.timestamps)
+
+			blockNumber, err := findBlockByTimestamp(context.Background(), headerByNumber, test.timestamp, test.end)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, blockNumber)
+			require.LessOrEqual(t, *calls, test.maxHeaderRead)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Types of the contracts, the outputs to be verified are read from.
+const (
+	OracleTypeL2OutputOracle     = "l2_output_oracle"
+	OracleTypeDisputeGameFactory = "dispute_game_factory"
+)
+
+// Statuses of a dispute game as defined in the dispute game contracts.
+const (
+	GameStatusInProgress     uint8 = 0
+	GameStatusChallengerWins uint8 = 1
+	GameStatusDefenderWins   uint8 = 2
+)
+
+// DisputeGame holds the address and current status of a dispute game.
+type DisputeGame struct {
+	Address common.Address
+	Status  uint8
+}
+
+// GameStatusToString returns human readable name of the given dispute game status.
+func GameStatusToString(status uint8) string {
+	switch status {
+	case GameStatusInProgress:
+		return "IN_PROGRESS"
+	case GameStatusChallengerWins:
+		return "CHALLENGER_WINS"
+	case GameStatusDefenderWins:
+		return "DEFENDER_WINS"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", status)
+	}
+}
+
+// DisputeGameFactoryAccessor binds dispute game factory contract to an instance for querying dispute games.
+// The index of a dispute game in the factory is used as the output index.
+type DisputeGameFactoryAccessor struct {
+	*blockPinner
+	client           *ethclient.Client
+	contractInstance *bindings.DisputeGameFactory
+	gameType         uint8
+}
+
+// NewDisputeGameFactoryAccessor returns [DisputeGameFactoryAccessor] with contract instance.
+func NewDisputeGameFactoryAccessor(ctx context.Context, opts *ConfigOptions) (*DisputeGameFactoryAccessor, error) {
+	if len(opts.DisputeGameFactoryContractAddress) == 0 {
+		return nil, fmt.Errorf("DisputeGameFactoryContractAddress is not available")
+	}
+
+	client, err := ethclient.DialContext(ctx, opts.L1RPCEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryContractInstance, err := bindings.NewDisputeGameFactory(common.HexToAddress(opts.DisputeGameFactoryContractAddress), client)
+	if err != nil {
+		return nil, err
+	}
+
+	pinner, err := newBlockPinner(client, opts.L1ReadDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DisputeGameFactoryAccessor{
+		blockPinner:      pinner,
+		client:           client,
+		contractInstance: factoryContractInstance,
+		gameType:         opts.DisputeGameType,
+	}, nil
+}
+
+// GetNextOutputIndex returns index of next dispute game to be created.
+func (dg *DisputeGameFactoryAccessor) GetNextOutputIndex() (*big.Int, error) {
+	return dg.contractInstance.GameCount(dg.callOpts())
+}
+
+// GetL2Output returns root claim and L2 block number of the dispute game at given index as L2 output.
+// The games of a type other than the configured one are returned as unmonitored outputs, without their root claim.
+func (dg *DisputeGameFactoryAccessor) GetL2Output(index *big.Int) (L2Output, error) {
+	game, err := dg.contractInstance.GameAtIndex(dg.callOpts(), index)
+	if err != nil {
+		return L2Output{}, err
+	}
+	if game.GameType != dg.gameType {
+		return L2Output{
+			L1Timestamp:   game.Timestamp,
+			L2OutputIndex: encoding.MustConvertBigIntToUint64(index),
+			Unmonitored:   true,
+		}, nil
+	}
+
+	gameContractInstance, err := bindings.NewFaultDisputeGameCaller(game.Proxy, dg.client)
+	if err != nil {
+		return L2Output{}, err
+	}
+
+	rootClaim, err := gameContractInstance.RootClaim(dg.callOpts())
+	if err != nil {
+		return L2Output{}, err
+	}
+
+	l2BlockNumber, err := gameContractInstance.L2BlockNumber(dg.callOpts())
+	if err != nil {
+		return L2Output{}, err
+	}
+
+	return L2Output{
+		OutputRoot:    hexutil.Encode(rootClaim[:]),
+		L1Timestamp:   game.Timestamp,
+		L2BlockNumber: encoding.MustConvertBigIntToUint64(l2BlockNumber),
+		L2OutputIndex: encoding.MustConvertBigIntToUint64(index),
+	}, nil
+}
+
+// FinalizationPeriodSeconds returns the duration of the dispute games of the configured game type in seconds.
+func (dg *DisputeGameFactoryAccessor) FinalizationPeriodSeconds() (*big.Int, error) {
+	gameImplAddress, err := dg.contractInstance.GameImpls(dg.callOpts(), dg.gameType)
+	if err != nil {
+		return nil, err
+	}
+	if gameImplAddress == (common.Address{}) {
+		return nil, fmt.Errorf("no implementation registered for dispute game type %d", dg.gameType)
+	}
+
+	gameImplContractInstance, err := bindings.NewFaultDisputeGameCaller(gameImplAddress, dg.client)
+	if err != nil {
+		return nil, err
+	}
+
+	gameDuration, err := gameImplContractInstance.GAMEDURATION(dg.callOpts())
+	if err != nil {
+		return nil, err
+	}
+
+	return encoding.MustConvertUint64ToBigInt(gameDuration), nil
+}
+
+// GetDisputeGame returns address and current status of the dispute game at given index.
+func (dg *DisputeGameFactoryAccessor) GetDisputeGame(index *big.Int) (DisputeGame, error) {
+	game, err := dg.contractInstance.GameAtIndex(dg.callOpts(), index)
+	if err != nil {
+		return DisputeGame{}, err
+	}
+
+	gameContractInstance, err := bindings.NewFaultDisputeGameCaller(game.Proxy, dg.client)
+	if err != nil {
+		return DisputeGame{}, err
+	}
+
+	status, err := gameContractInstance.Status(dg.callOpts())
+	if err != nil {
+		return DisputeGame{}, err
+	}
+
+	return DisputeGame{
+		Address: game.Proxy,
+		Status:  status,
+	}, nil
+}
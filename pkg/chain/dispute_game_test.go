@@ -0,0 +1,213 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameStatusToString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("IN_PROGRESS", GameStatusToString(GameStatusInProgress))
+	assert.Equal("CHALLENGER_WINS", GameStatusToString(GameStatusChallengerWins))
+	assert.Equal("DEFENDER_WINS", GameStatusToString(GameStatusDefenderWins))
+	assert.Equal("UNKNOWN(3)", GameStatusToString(3))
+}
+
+// fakeDisputeGames serves the calls of the `DisputeGameFactory` contract and of the games created by it, as an L1 node would.
+type fakeDisputeGames struct {
+	factoryAddress common.Address
+	factoryABI     *abi.ABI
+	gameABI        *abi.ABI
+	gameImpls      map[uint8]common.Address
+	gameDuration   uint64
+	games          []fakeDisputeGame
+}
+
+type fakeDisputeGame struct {
+	gameType      uint8
+	proxy         common.Address
+	timestamp     uint64
+	rootClaim     common.Hash
+	l2BlockNumber uint64
+	status        uint8
+}
+
+// fakeCallArgs holds the fields of the `eth_call` transaction object used by the fake contracts.
+type fakeCallArgs struct {
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+func (f *fakeDisputeGames) Call(args fakeCallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	if args.To == nil || len(data) < 4 {
+		return nil, errors.New("execution reverted")
+	}
+
+	if *args.To == f.factoryAddress {
+		method, err := f.factoryABI.MethodById(data[:4])
+		if err != nil {
+			return nil, err
+		}
+		inputs, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		switch method.RawName {
+		case "gameCount":
+			return method.Outputs.Pack(big.NewInt(int64(len(f.games))))
+		case "gameAtIndex":
+			index := inputs[0].(*big.Int)
+			if !index.IsUint64() || index.Uint64() >= uint64(len(f.games)) {
+				return nil, errors.New("execution reverted")
+			}
+			game := f.games[index.Uint64()]
+			return method.Outputs.Pack(game.gameType, game.timestamp, game.proxy)
+		case "gameImpls":
+			return method.Outputs.Pack(f.gameImpls[inputs[0].(uint8)])
+		}
+		return nil, errors.New("execution reverted")
+	}
+
+	method, err := f.gameABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if method.RawName == "GAME_DURATION" {
+		for _, impl := range f.gameImpls {
+			if impl == *args.To {
+				return method.Outputs.Pack(f.gameDuration)
+			}
+		}
+	}
+	for _, game := range f.games {
+		if game.proxy != *args.To {
+			continue
+		}
+		switch method.RawName {
+		case "rootClaim":
+			return method.Outputs.Pack(game.rootClaim)
+		case "l2BlockNumber":
+			return method.Outputs.Pack(new(big.Int).SetUint64(game.l2BlockNumber))
+		case "status":
+			return method.Outputs.Pack(game.status)
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+// newTestDisputeGameFactoryAccessor returns [DisputeGameFactoryAccessor] connected to a JSON-RPC server serving the given fake dispute games.
+func newTestDisputeGameFactoryAccessor(t *testing.T, disputeGames *fakeDisputeGames, gameType uint8) *DisputeGameFactoryAccessor {
+	var err error
+	disputeGames.factoryABI, err = bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	disputeGames.gameABI, err = bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("eth", disputeGames))
+	httpServer := httptest.NewServer(rpcServer)
+	t.Cleanup(func() {
+		httpServer.Close()
+		rpcServer.Stop()
+	})
+
+	accessor, err := NewDisputeGameFactoryAccessor(context.Background(), &ConfigOptions{
+		L1RPCEndpoint:                     httpServer.URL,
+		DisputeGameFactoryContractAddress: disputeGames.factoryAddress.Hex(),
+		DisputeGameType:                   gameType,
+	})
+	require.NoError(t, err)
+	return accessor
+}
+
+// newTestDisputeGames returns a resolved and an in progress dispute game, created with the registered game type 0, and an in progress game of type 1.
+func newTestDisputeGames() *fakeDisputeGames {
+	return &fakeDisputeGames{
+		factoryAddress: common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1"),
+		gameImpls:      map[uint8]common.Address{0: common.HexToAddress("0x1111111111111111111111111111111111111111")},
+		gameDuration:   302400,
+		games: []fakeDisputeGame{
+			{proxy: common.HexToAddress("0x2222222222222222222222222222222222222222"), timestamp: 1700000000, rootClaim: common.HexToHash("0x01"), l2BlockNumber: 1800, status: GameStatusDefenderWins},
+			{proxy: common.HexToAddress("0x3333333333333333333333333333333333333333"), timestamp: 1700003600, rootClaim: common.HexToHash("0x02"), l2BlockNumber: 3600, status: GameStatusInProgress},
+			{gameType: 1, proxy: common.HexToAddress("0x4444444444444444444444444444444444444444"), timestamp: 1700007200, rootClaim: common.HexToHash("0x03"), l2BlockNumber: 5400, status: GameStatusInProgress},
+		},
+	}
+}
+
+func TestDisputeGameFactoryAccessor_GetL2Output(t *testing.T) {
+	dg := newTestDisputeGameFactoryAccessor(t, newTestDisputeGames(), 0)
+
+	nextOutputIndex, err := dg.GetNextOutputIndex()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), nextOutputIndex)
+
+	output, err := dg.GetL2Output(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, L2Output{
+		OutputRoot:    common.HexToHash("0x02").Hex(),
+		L1Timestamp:   1700003600,
+		L2BlockNumber: 3600,
+		L2OutputIndex: 1,
+	}, output)
+
+	// The game of another type is not verified
+	output, err = dg.GetL2Output(big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, L2Output{
+		L1Timestamp:   1700007200,
+		L2OutputIndex: 2,
+		Unmonitored:   true,
+	}, output)
+
+	_, err = dg.GetL2Output(big.NewInt(3))
+	require.Error(t, err)
+}
+
+func TestDisputeGameFactoryAccessor_FinalizationPeriodSeconds(t *testing.T) {
+	t.Run("should return the duration of the games of the configured type", func(t *testing.T) {
+		dg := newTestDisputeGameFactoryAccessor(t, newTestDisputeGames(), 0)
+
+		finalizationPeriodSeconds, err := dg.FinalizationPeriodSeconds()
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(302400), finalizationPeriodSeconds)
+	})
+
+	t.Run("should return error when no implementation is registered for the configured type", func(t *testing.T) {
+		dg := newTestDisputeGameFactoryAccessor(t, newTestDisputeGames(), 1)
+
+		_, err := dg.FinalizationPeriodSeconds()
+		require.EqualError(t, err, "no implementation registered for dispute game type 1")
+	})
+}
+
+func TestDisputeGameFactoryAccessor_GetDisputeGame(t *testing.T) {
+	dg := newTestDisputeGameFactoryAccessor(t, newTestDisputeGames(), 0)
+
+	game, err := dg.GetDisputeGame(big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, DisputeGame{Address: common.HexToAddress("0x2222222222222222222222222222222222222222"), Status: GameStatusDefenderWins}, game)
+
+	game, err = dg.GetDisputeGame(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, DisputeGame{Address: common.HexToAddress("0x3333333333333333333333333333333333333333"), Status: GameStatusInProgress}, game)
+
+	_, err = dg.GetDisputeGame(big.NewInt(3))
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// L1 block tags the oracle reads can be performed at, alternatively a number of confirmations below the latest block.
+const (
+	ReadDepthLatest    = "latest"
+	ReadDepthSafe      = "safe"
+	ReadDepthFinalized = "finalized"
+)
+
+// ReadDepth is the depth of the L1 block the oracle reads are performed at.
+type ReadDepth struct {
+	tag           rpc.BlockNumber
+	confirmations uint64
+}
+
+// ParseReadDepth parses the given read depth, either one of `latest`, `safe` and `finalized` or a number of confirmations.
+// An empty read depth defaults to `latest`.
+func ParseReadDepth(readDepth string) (ReadDepth, error) {
+	switch readDepth {
+	case "", ReadDepthLatest:
+		return ReadDepth{tag: rpc.LatestBlockNumber}, nil
+	case ReadDepthSafe:
+		return ReadDepth{tag: rpc.SafeBlockNumber}, nil
+	case ReadDepthFinalized:
+		return ReadDepth{tag: rpc.FinalizedBlockNumber}, nil
+	}
+
+	confirmations, err := strconv.ParseUint(readDepth, 10, 64)
+	if err != nil {
+		return ReadDepth{}, fmt.Errorf("invalid read depth: '%s', expected one of %s, %s, %s or a number of confirmations", readDepth, ReadDepthLatest, ReadDepthSafe, ReadDepthFinalized)
+	}
+
+	return ReadDepth{tag: rpc.LatestBlockNumber, confirmations: confirmations}, nil
+}
+
+// String returns human readable representation of the read depth.
+func (d ReadDepth) String() string {
+	if d.confirmations > 0 {
+		return fmt.Sprintf("%d confirmations", d.confirmations)
+	}
+	return d.tag.String()
+}
+
+// blockPinner resolves the L1 block at the read depth and pins the oracle reads to it.
+type blockPinner struct {
+	client      *ethclient.Client
+	readDepth   ReadDepth
+	mutex       sync.RWMutex
+	blockNumber *big.Int
+}
+
+func newBlockPinner(client *ethclient.Client, readDepth string) (*blockPinner, error) {
+	depth, err := ParseReadDepth(readDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockPinner{
+		client:    client,
+		readDepth: depth,
+	}, nil
+}
+
+// PinL1Block resolves the L1 block at the read depth and returns its header.
+// All the subsequent oracle reads are performed at the returned block, until pinned again.
+func (p *blockPinner) PinL1Block(ctx context.Context) (*types.Header, error) {
+	header, err := p.client.HeaderByNumber(ctx, big.NewInt(p.readDepth.tag.Int64()))
+	if err != nil {
+		return nil, err
+	}
+
+	if p.readDepth.confirmations > 0 {
+		latestBlockNumber := header.Number.Uint64()
+		if latestBlockNumber < p.readDepth.confirmations {
+			return nil, fmt.Errorf("L1 latest block number %d is lower than the read depth of %s", latestBlockNumber, p.readDepth)
+		}
+
+		header, err = p.client.HeaderByNumber(ctx, encoding.MustConvertUint64ToBigInt(latestBlockNumber-p.readDepth.confirmations))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.mutex.Lock()
+	p.blockNumber = header.Number
+	p.mutex.Unlock()
+
+	return header, nil
+}
+
+// callOpts returns the options to perform a contract call at the pinned L1 block, or at the latest block when not pinned yet.
+func (p *blockPinner) callOpts() *bind.CallOpts {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return &bind.CallOpts{BlockNumber: p.blockNumber}
+}
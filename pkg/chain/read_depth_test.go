@@ -0,0 +1,28 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReadDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	for readDepth, expected := range map[string]string{
+		"":          "latest",
+		"latest":    "latest",
+		"safe":      "safe",
+		"finalized": "finalized",
+		"12":        "12 confirmations",
+	} {
+		depth, err := ParseReadDepth(readDepth)
+		assert.NoError(err)
+		assert.Equal(expected, depth.String())
+	}
+
+	_, err := ParseReadDepth("pending")
+	assert.Error(err)
+	_, err = ParseReadDepth("-1")
+	assert.Error(err)
+}
@@ -0,0 +1,67 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	rpcEndpointOutputAtBlock = "optimism_outputAtBlock"
+)
+
+// Strategies used to compute the output roots from the local view.
+const (
+	VerifierTypeProof      = "proof"
+	VerifierTypeRollupNode = "rollup_node"
+	VerifierTypeBoth       = "both"
+)
+
+// L2BlockRef is the reference to an L2 block, as returned by the rollup node.
+type L2BlockRef struct {
+	Hash       common.Hash `json:"hash"`
+	Number     uint64      `json:"number"`
+	ParentHash common.Hash `json:"parentHash"`
+	Timestamp  uint64      `json:"timestamp"`
+}
+
+// OutputResponse is the output at an L2 block, as returned by the rollup node.
+type OutputResponse struct {
+	OutputRoot            common.Hash `json:"outputRoot"`
+	BlockRef              L2BlockRef  `json:"blockRef"`
+	WithdrawalStorageRoot common.Hash `json:"withdrawalStorageRoot"`
+	StateRoot             common.Hash `json:"stateRoot"`
+}
+
+// RollupNodeClient connects and encapsulates all the methods to interact with a rollup node, i.e. op-node.
+type RollupNodeClient struct {
+	rpc *rpc.Client
+	log log.Logger
+}
+
+// GetRollupNodeClient returns [RollupNodeClient] with client attached.
+func GetRollupNodeClient(ctx context.Context, url string, log log.Logger) (*RollupNodeClient, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RollupNodeClient{
+		rpc: client,
+		log: log,
+	}, nil
+}
+
+// OutputAtBlock returns the output at a given L2 block number from a connected rollup node.
+func (c *RollupNodeClient) OutputAtBlock(ctx context.Context, blockNumber uint64) (*OutputResponse, error) {
+	var result OutputResponse
+
+	if err := c.rpc.CallContext(ctx, &result, rpcEndpointOutputAtBlock, hexutil.Uint64(blockNumber)); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -6,7 +6,9 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
 	"github.com/LiskHQ/op-fault-detector/pkg/log"
 	"github.com/LiskHQ/op-fault-detector/pkg/utils"
 	"go.uber.org/multierr"
@@ -19,15 +21,26 @@ var (
 	basePathRegex         = regexp.MustCompile(`^/?api$`)
 	registerVersionRegex  = regexp.MustCompile(`^v[1-9]\d*$`)
 	slackChannelID        = regexp.MustCompile(`^[A-Za-z0-9]{1,255}$`)
+	chainNameRegex        = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+// Defaults of the scheduler intervals, used when not set in the config file.
+const (
+	defaultFaultRecheckInterval   = 10 * time.Second
+	defaultL2NodeBehindInterval   = 10 * time.Second
+	defaultBackoffInitialInterval = time.Second
+	defaultBackoffMaxInterval     = 5 * time.Minute
+	defaultBackoffMultiplier      = 2
+	defaultBackoffJitter          = 0.2
 )
 
 // Config struct is used to store the contents of the parsed config file.
 // The properties (sub-properties) should map on-to-one with the config file.
 type Config struct {
-	System              *System              `mapstructure:"system"`
-	Api                 *Api                 `mapstructure:"api"`
-	FaultDetectorConfig *FaultDetectorConfig `mapstructure:"fault_detector"`
-	Notification        *Notification        `mapstructure:"notification"`
+	System               *System                `mapstructure:"system"`
+	Api                  *Api                   `mapstructure:"api"`
+	FaultDetectorConfigs []*FaultDetectorConfig `mapstructure:"fault_detector"`
+	Notification         *Notification          `mapstructure:"notification"`
 }
 
 // System struct is used to store the contents of the 'system' property from the parsed config file.
@@ -48,12 +61,107 @@ type Server struct {
 	Port uint   `mapstructure:"port"`
 }
 
-// FaultDetector struct is used to store the contents of the 'fault_detector' property from the parsed config file.
+// FaultDetectorConfig struct is used to store the contents of each chain entry of the 'fault_detector' property from the parsed config file.
 type FaultDetectorConfig struct {
-	L1RPCEndpoint                 string `mapstructure:"l1_rpc_endpoint"`
-	L2RPCEndpoint                 string `mapstructure:"l2_rpc_endpoint"`
-	StartBatchIndex               int64  `mapstructure:"start_batch_index"`
-	L2OutputOracleContractAddress string `mapstructure:"l2_output_oracle_contract_address"`
+	Name                              string                  `mapstructure:"name"`
+	L1RPCEndpoint                     string                  `mapstructure:"l1_rpc_endpoint"`
+	L2RPCEndpoint                     string                  `mapstructure:"l2_rpc_endpoint"`
+	L2RPCEndpoints                    []string                `mapstructure:"l2_rpc_endpoints"`
+	Quorum                            uint                    `mapstructure:"quorum"`
+	StartBatchIndex                   int64                   `mapstructure:"start_batch_index"`
+	ContinuePastFaults                bool                    `mapstructure:"continue_past_faults"`
+	L2OutputOracleContractAddress     string                  `mapstructure:"l2_output_oracle_contract_address"`
+	OracleType                        string                  `mapstructure:"oracle_type"`
+	DisputeGameFactoryContractAddress string                  `mapstructure:"dispute_game_factory_contract_address"`
+	DisputeGameType                   uint8                   `mapstructure:"dispute_game_type"`
+	L1ReadDepth                       string                  `mapstructure:"l1_read_depth"`
+	Verifier                          string                  `mapstructure:"verifier"`
+	RollupNodeRPCEndpoint             string                  `mapstructure:"rollup_node_rpc_endpoint"`
+	Checkpoint                        *Checkpoint             `mapstructure:"checkpoint"`
+	FaultHistory                      *FaultHistory           `mapstructure:"fault_history"`
+	Evidence                          *Evidence               `mapstructure:"evidence"`
+	CatchUp                           *CatchUp                `mapstructure:"catch_up"`
+	Scheduler                         *Scheduler              `mapstructure:"scheduler"`
+	ProposerLiveness                  *ProposerLiveness       `mapstructure:"proposer_liveness"`
+	ProposerCheck                     *ProposerCheck          `mapstructure:"proposer_check"`
+	OracleParametersCheck             *OracleParametersCheck  `mapstructure:"oracle_parameters_check"`
+	OutputInvariantsCheck             *OutputInvariantsCheck  `mapstructure:"output_invariants_check"`
+	FinalizationEscalation            *FinalizationEscalation `mapstructure:"finalization_escalation"`
+}
+
+// Checkpoint struct is used to store the contents of the 'fault_detector.checkpoint' sub-property from the parsed config file.
+type Checkpoint struct {
+	Enable               bool   `mapstructure:"enable"`
+	Directory            string `mapstructure:"directory"`
+	ResumeFromCheckpoint bool   `mapstructure:"resume_from_checkpoint"`
+}
+
+// FaultHistory struct is used to store the contents of the 'fault_detector.fault_history' sub-property from the parsed config file.
+type FaultHistory struct {
+	Enable    bool   `mapstructure:"enable"`
+	Directory string `mapstructure:"directory"`
+}
+
+// Evidence struct is used to store the contents of the 'fault_detector.evidence' sub-property from the parsed config file.
+type Evidence struct {
+	Enable    bool   `mapstructure:"enable"`
+	Directory string `mapstructure:"directory"`
+}
+
+// CatchUp struct is used to store the contents of the 'fault_detector.catch_up' sub-property from the parsed config file.
+type CatchUp struct {
+	Enable     bool   `mapstructure:"enable"`
+	Threshold  uint64 `mapstructure:"threshold"`
+	WindowSize uint64 `mapstructure:"window_size"`
+	Workers    uint   `mapstructure:"workers"`
+}
+
+// ProposerLiveness struct is used to store the contents of the 'fault_detector.proposer_liveness' sub-property from the parsed config file.
+type ProposerLiveness struct {
+	Enable             bool    `mapstructure:"enable"`
+	IntervalMultiplier float64 `mapstructure:"interval_multiplier"`
+}
+
+// ProposerCheck struct is used to store the contents of the 'fault_detector.proposer_check' sub-property from the parsed config file.
+type ProposerCheck struct {
+	Enable    bool     `mapstructure:"enable"`
+	Allowlist []string `mapstructure:"allowlist"`
+}
+
+// OutputInvariantsCheck struct is used to store the contents of the 'fault_detector.output_invariants_check' sub-property from the parsed config file.
+type OutputInvariantsCheck struct {
+	Enable bool `mapstructure:"enable"`
+}
+
+// OracleParametersCheck struct is used to store the contents of the 'fault_detector.oracle_parameters_check' sub-property from the parsed config file.
+type OracleParametersCheck struct {
+	Enable   bool          `mapstructure:"enable"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// FinalizationEscalation struct is used to store the contents of the 'fault_detector.finalization_escalation' sub-property from the parsed config file.
+type FinalizationEscalation struct {
+	Enable     bool                   `mapstructure:"enable"`
+	Thresholds []*EscalationThreshold `mapstructure:"thresholds"`
+}
+
+// EscalationThreshold struct is used to store each entry of the 'fault_detector.finalization_escalation.thresholds' sub-property from the parsed config file.
+type EscalationThreshold struct {
+	Before         time.Duration `mapstructure:"before"`
+	Severity       string        `mapstructure:"severity"`
+	SlackChannelID string        `mapstructure:"slack_channel_id"`
+}
+
+// Scheduler struct is used to store the contents of the 'fault_detector.scheduler' sub-property from the parsed config file.
+type Scheduler struct {
+	BehindInterval         time.Duration `mapstructure:"behind_interval"`
+	ProposalInterval       time.Duration `mapstructure:"proposal_interval"`
+	FaultRecheckInterval   time.Duration `mapstructure:"fault_recheck_interval"`
+	L2NodeBehindInterval   time.Duration `mapstructure:"l2_node_behind_interval"`
+	BackoffInitialInterval time.Duration `mapstructure:"backoff_initial_interval"`
+	BackoffMaxInterval     time.Duration `mapstructure:"backoff_max_interval"`
+	BackoffMultiplier      float64       `mapstructure:"backoff_multiplier"`
+	BackoffJitter          float64       `mapstructure:"backoff_jitter"`
 }
 
 // SlackConfig struct is used to store slack configurations from the parsed config file.
@@ -85,7 +193,7 @@ func (c *Config) Validate() error {
 
 	sysConfigError := c.System.Validate()
 	apiConfigError := c.Api.Validate()
-	fdConfigError := c.FaultDetectorConfig.Validate()
+	fdConfigError := validateFaultDetectorConfigs(c.FaultDetectorConfigs)
 	// Validate notification config only when it is enabled
 	var notificationConfigError error
 	if c.Notification.Enable {
@@ -152,24 +260,336 @@ func (c *Server) Validate() error {
 	return validationErrors
 }
 
-// Validate runs validations against an instance of the FaultDetector struct and returns an error when applicable.
+// validateFaultDetectorConfigs runs validations against all the chain entries of the 'fault_detector' property and returns an error when applicable.
+func validateFaultDetectorConfigs(configs []*FaultDetectorConfig) error {
+	var validationErrors error
+
+	if len(configs) == 0 {
+		return fmt.Errorf("faultdetector expected at least one chain entry, received: 0")
+	}
+
+	chainNames := []string{}
+	for _, fdConfig := range configs {
+		validationErrors = multierr.Append(validationErrors, fdConfig.Validate())
+
+		if len(fdConfig.Name) == 0 {
+			continue
+		}
+		if utils.Contains(chainNames, fdConfig.Name) {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.name expected to be unique, received duplicate: '%s'", fdConfig.Name))
+		}
+		chainNames = append(chainNames, fdConfig.Name)
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the FaultDetectorConfig struct and returns an error when applicable.
 func (c *FaultDetectorConfig) Validate() error {
 	var validationErrors error
 
+	if len(c.Name) > 0 && !chainNameRegex.MatchString(c.Name) {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.name expected to match regex: `%s`, received: '%s'", chainNameRegex.String(), c.Name))
+	}
+
 	l1ProviderMatched := providerEndpointRegex.MatchString(c.L1RPCEndpoint)
 	if !l1ProviderMatched {
 		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l1_rpc_endpoint expected to match regex: `%s`, received: '%s'", providerEndpointRegex.String(), c.L1RPCEndpoint))
 	}
-	l2ProviderMatched := providerEndpointRegex.MatchString(c.L2RPCEndpoint)
-	if !l2ProviderMatched {
-		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l2_rpc_endpoint expected to match regex: `%s`, received: '%s'", providerEndpointRegex.String(), c.L2RPCEndpoint))
+	// Validate the single L2 endpoint only when multiple L2 endpoints are not provided
+	if len(c.L2RPCEndpoints) == 0 {
+		l2ProviderMatched := providerEndpointRegex.MatchString(c.L2RPCEndpoint)
+		if !l2ProviderMatched {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l2_rpc_endpoint expected to match regex: `%s`, received: '%s'", providerEndpointRegex.String(), c.L2RPCEndpoint))
+		}
+	}
+	for _, l2RPCEndpoint := range c.L2RPCEndpoints {
+		if !providerEndpointRegex.MatchString(l2RPCEndpoint) {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l2_rpc_endpoints entry expected to match regex: `%s`, received: '%s'", providerEndpointRegex.String(), l2RPCEndpoint))
+		}
+	}
+
+	l2RPCEndpointsCount := uint(len(c.GetL2RPCEndpoints()))
+	if c.Quorum > l2RPCEndpointsCount {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.quorum expected in range: 0 - %d, received: %d", l2RPCEndpointsCount, c.Quorum))
+	}
+	// A quorum of at most half of the endpoints can be reached by two different output roots
+	if c.Quorum > 0 && c.Quorum <= l2RPCEndpointsCount/2 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.quorum expected to be a majority of the %d l2 rpc endpoints, at least %d, received: %d", l2RPCEndpointsCount, l2RPCEndpointsCount/2+1, c.Quorum))
 	}
 
-	addressMatched := addressRegex.MatchString(c.L2OutputOracleContractAddress)
-	if !addressMatched {
+	// The L2OutputOracle contract address is not used when the outputs are read from the dispute games
+	isL2OutputOracle := len(c.OracleType) == 0 || c.OracleType == chain.OracleTypeL2OutputOracle
+	if isL2OutputOracle && !addressRegex.MatchString(c.L2OutputOracleContractAddress) {
 		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l2_output_oracle_contract_address expected to match regex: `%s`, received: '%s'", addressRegex.String(), c.L2OutputOracleContractAddress))
 	}
 
+	allowedOracleTypes := []string{chain.OracleTypeL2OutputOracle, chain.OracleTypeDisputeGameFactory}
+	if len(c.OracleType) > 0 && !utils.Contains(allowedOracleTypes, c.OracleType) {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.oracle_type expected one of %s, received: '%s'", allowedOracleTypes, c.OracleType))
+	}
+
+	if c.OracleType == chain.OracleTypeDisputeGameFactory && !addressRegex.MatchString(c.DisputeGameFactoryContractAddress) {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.dispute_game_factory_contract_address expected to match regex: `%s`, received: '%s'", addressRegex.String(), c.DisputeGameFactoryContractAddress))
+	}
+
+	allowedVerifiers := []string{chain.VerifierTypeProof, chain.VerifierTypeRollupNode, chain.VerifierTypeBoth}
+	if len(c.Verifier) > 0 && !utils.Contains(allowedVerifiers, c.Verifier) {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.verifier expected one of %s, received: '%s'", allowedVerifiers, c.Verifier))
+	}
+
+	if (c.Verifier == chain.VerifierTypeRollupNode || c.Verifier == chain.VerifierTypeBoth) && !providerEndpointRegex.MatchString(c.RollupNodeRPCEndpoint) {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.rollup_node_rpc_endpoint expected to match regex: `%s`, received: '%s'", providerEndpointRegex.String(), c.RollupNodeRPCEndpoint))
+	}
+
+	if _, err := chain.ParseReadDepth(c.L1ReadDepth); err != nil {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.l1_read_depth expected one of [%s %s %s] or a number of confirmations, received: '%s'", chain.ReadDepthLatest, chain.ReadDepthSafe, chain.ReadDepthFinalized, c.L1ReadDepth))
+	}
+
+	// Validate checkpoint config only when it is enabled
+	if c.Checkpoint != nil && c.Checkpoint.Enable {
+		validationErrors = multierr.Append(validationErrors, c.Checkpoint.Validate())
+	}
+
+	// Validate fault history config only when it is enabled
+	if c.FaultHistory != nil && c.FaultHistory.Enable {
+		validationErrors = multierr.Append(validationErrors, c.FaultHistory.Validate())
+	}
+
+	// Validate evidence config only when it is enabled, the evidence is not available when the output roots are queried from the rollup node
+	if c.Evidence != nil && c.Evidence.Enable {
+		validationErrors = multierr.Append(validationErrors, c.Evidence.Validate())
+		if c.Verifier == chain.VerifierTypeRollupNode {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.evidence expected to be disabled with verifier: '%s'", chain.VerifierTypeRollupNode))
+		}
+	}
+
+	// Validate catch-up config only when it is enabled
+	if c.CatchUp != nil && c.CatchUp.Enable {
+		validationErrors = multierr.Append(validationErrors, c.CatchUp.Validate())
+	}
+
+	if c.Scheduler != nil {
+		validationErrors = multierr.Append(validationErrors, c.Scheduler.Validate())
+	}
+
+	// Validate proposer liveness config only when it is enabled
+	if c.ProposerLiveness != nil && c.ProposerLiveness.Enable {
+		validationErrors = multierr.Append(validationErrors, c.ProposerLiveness.Validate())
+	}
+
+	// Validate proposer check config only when it is enabled
+	if c.ProposerCheck != nil && c.ProposerCheck.Enable {
+		validationErrors = multierr.Append(validationErrors, c.ProposerCheck.Validate())
+	}
+
+	// Validate oracle parameters check config only when it is enabled
+	if c.OracleParametersCheck != nil && c.OracleParametersCheck.Enable {
+		validationErrors = multierr.Append(validationErrors, c.OracleParametersCheck.Validate())
+	}
+
+	// Validate finalization escalation config only when it is enabled
+	if c.FinalizationEscalation != nil && c.FinalizationEscalation.Enable {
+		validationErrors = multierr.Append(validationErrors, c.FinalizationEscalation.Validate())
+	}
+
+	return validationErrors
+}
+
+// GetL2RPCEndpoints returns the L2 endpoints the output roots are computed with, 'l2_rpc_endpoints' takes precedence over 'l2_rpc_endpoint'.
+func (c *FaultDetectorConfig) GetL2RPCEndpoints() []string {
+	if len(c.L2RPCEndpoints) > 0 {
+		return c.L2RPCEndpoints
+	}
+
+	return []string{c.L2RPCEndpoint}
+}
+
+// GetQuorum returns the number of L2 endpoints required to agree on an output root, by default the majority of the endpoints.
+func (c *FaultDetectorConfig) GetQuorum() uint {
+	if c.Quorum > 0 {
+		return c.Quorum
+	}
+
+	return uint(len(c.GetL2RPCEndpoints()))/2 + 1
+}
+
+// GetScheduler returns the scheduler config with the unset intervals set to their defaults.
+// The proposal interval is left unset by default, to be derived from the oracle submission interval.
+func (c *FaultDetectorConfig) GetScheduler() *Scheduler {
+	scheduler := &Scheduler{}
+	if c.Scheduler != nil {
+		*scheduler = *c.Scheduler
+	}
+
+	if scheduler.FaultRecheckInterval == 0 {
+		scheduler.FaultRecheckInterval = defaultFaultRecheckInterval
+	}
+	if scheduler.L2NodeBehindInterval == 0 {
+		scheduler.L2NodeBehindInterval = defaultL2NodeBehindInterval
+	}
+	if scheduler.BackoffInitialInterval == 0 {
+		scheduler.BackoffInitialInterval = defaultBackoffInitialInterval
+	}
+	if scheduler.BackoffMaxInterval == 0 {
+		scheduler.BackoffMaxInterval = defaultBackoffMaxInterval
+	}
+	if scheduler.BackoffMultiplier == 0 {
+		scheduler.BackoffMultiplier = defaultBackoffMultiplier
+	}
+	if scheduler.BackoffJitter == 0 {
+		scheduler.BackoffJitter = defaultBackoffJitter
+	}
+
+	return scheduler
+}
+
+// Validate runs validations against an instance of the Checkpoint struct and returns an error when applicable.
+func (c *Checkpoint) Validate() error {
+	var validationErrors error
+
+	if len(strings.TrimSpace(c.Directory)) == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.checkpoint.directory expected to be non-empty, received: '%s'", c.Directory))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the FaultHistory struct and returns an error when applicable.
+func (c *FaultHistory) Validate() error {
+	var validationErrors error
+
+	if len(strings.TrimSpace(c.Directory)) == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.fault_history.directory expected to be non-empty, received: '%s'", c.Directory))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the Evidence struct and returns an error when applicable.
+func (c *Evidence) Validate() error {
+	var validationErrors error
+
+	if len(strings.TrimSpace(c.Directory)) == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.evidence.directory expected to be non-empty, received: '%s'", c.Directory))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the Scheduler struct and returns an error when applicable.
+func (c *Scheduler) Validate() error {
+	var validationErrors error
+
+	intervals := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{"behind_interval", c.BehindInterval},
+		{"proposal_interval", c.ProposalInterval},
+		{"fault_recheck_interval", c.FaultRecheckInterval},
+		{"l2_node_behind_interval", c.L2NodeBehindInterval},
+		{"backoff_initial_interval", c.BackoffInitialInterval},
+		{"backoff_max_interval", c.BackoffMaxInterval},
+	}
+	for _, interval := range intervals {
+		if interval.interval < 0 {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.scheduler.%s expected to be non-negative, received: '%s'", interval.name, interval.interval))
+		}
+	}
+
+	if c.BackoffMaxInterval > 0 && c.BackoffMaxInterval < c.BackoffInitialInterval {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.scheduler.backoff_max_interval expected to be greater than or equal to faultdetector.scheduler.backoff_initial_interval, received: '%s'", c.BackoffMaxInterval))
+	}
+
+	if c.BackoffMultiplier != 0 && c.BackoffMultiplier < 1 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.scheduler.backoff_multiplier expected to be greater than or equal to 1, received: %v", c.BackoffMultiplier))
+	}
+
+	if c.BackoffJitter < 0 || c.BackoffJitter > 1 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.scheduler.backoff_jitter expected in range: 0 - 1, received: %v", c.BackoffJitter))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the CatchUp struct and returns an error when applicable.
+func (c *CatchUp) Validate() error {
+	var validationErrors error
+
+	if c.Threshold == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.catch_up.threshold expected to be greater than 0, received: %d", c.Threshold))
+	}
+	if c.WindowSize == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.catch_up.window_size expected to be greater than 0, received: %d", c.WindowSize))
+	}
+	if c.Workers == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.catch_up.workers expected to be greater than 0, received: %d", c.Workers))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the ProposerLiveness struct and returns an error when applicable.
+func (c *ProposerLiveness) Validate() error {
+	var validationErrors error
+
+	if c.IntervalMultiplier < 1 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.proposer_liveness.interval_multiplier expected to be greater than or equal to 1, received: %v", c.IntervalMultiplier))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the ProposerCheck struct and returns an error when applicable.
+func (c *ProposerCheck) Validate() error {
+	var validationErrors error
+
+	for _, address := range c.Allowlist {
+		if !addressRegex.MatchString(address) {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.proposer_check.allowlist entry expected to match regex: `%s`, received: '%s'", addressRegex.String(), address))
+		}
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the OracleParametersCheck struct and returns an error when applicable.
+func (c *OracleParametersCheck) Validate() error {
+	var validationErrors error
+
+	if c.Interval <= 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.oracle_parameters_check.interval expected to be greater than 0, received: %s", c.Interval))
+	}
+
+	return validationErrors
+}
+
+// Validate runs validations against an instance of the FinalizationEscalation struct and returns an error when applicable.
+func (c *FinalizationEscalation) Validate() error {
+	var validationErrors error
+
+	if len(c.Thresholds) == 0 {
+		validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.finalization_escalation.thresholds expected to be non-empty"))
+	}
+
+	befores := make(map[time.Duration]bool, len(c.Thresholds))
+	for _, threshold := range c.Thresholds {
+		if threshold.Before <= 0 {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.finalization_escalation.thresholds[].before expected to be greater than 0, received: %s", threshold.Before))
+		} else if befores[threshold.Before] {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.finalization_escalation.thresholds[].before expected to be unique, received: %s", threshold.Before))
+		}
+		befores[threshold.Before] = true
+
+		if len(threshold.Severity) == 0 {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.finalization_escalation.thresholds[].severity expected to be non-empty, received: ''"))
+		}
+		if len(threshold.SlackChannelID) > 0 && !slackChannelID.MatchString(threshold.SlackChannelID) {
+			validationErrors = multierr.Append(validationErrors, fmt.Errorf("faultdetector.finalization_escalation.thresholds[].slack_channel_id expected to match regex: `%s`, received: '%s'", slackChannelID.String(), threshold.SlackChannelID))
+		}
+	}
+
 	return validationErrors
 }
 
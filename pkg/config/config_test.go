@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/LiskHQ/op-fault-detector/pkg/log"
 	"github.com/magiconair/properties/assert"
@@ -297,6 +298,466 @@ func TestValidate_FaultDetector(t *testing.T) {
 			},
 			want: fmt.Errorf("faultdetector.l2_output_oracle_contract_address expected to match regex: `%s`, received: 'xx0000000000000000000000000000000000000000'", addressRegex.String()),
 		},
+		{
+			name: "should return nil when dispute game factory oracle type is given with valid address",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                     "http://xyz.com",
+				L2RPCEndpoint:                     "http://xyz.com",
+				StartBatchIndex:                   100,
+				L2OutputOracleContractAddress:     "0x0000000000000000000000000000000000000000",
+				OracleType:                        "dispute_game_factory",
+				DisputeGameFactoryContractAddress: "0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1",
+			},
+			want: nil,
+		},
+		{
+			name: "should return nil when dispute game factory oracle type is given without l2 output oracle address",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                     "http://xyz.com",
+				L2RPCEndpoint:                     "http://xyz.com",
+				StartBatchIndex:                   100,
+				OracleType:                        "dispute_game_factory",
+				DisputeGameFactoryContractAddress: "0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1",
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when invalid oracle type is given",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				OracleType:                    "fault_proof",
+			},
+			want: fmt.Errorf("faultdetector.oracle_type expected one of %s, received: 'fault_proof'", []string{"l2_output_oracle", "dispute_game_factory"}),
+		},
+		{
+			name: "should return error when dispute game factory oracle type is given with invalid address",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				OracleType:                    "dispute_game_factory",
+			},
+			want: fmt.Errorf("faultdetector.dispute_game_factory_contract_address expected to match regex: `%s`, received: ''", addressRegex.String()),
+		},
+		{
+			name: "should return nil when l1 read depth is given as number of confirmations",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				L1ReadDepth:                   "12",
+			},
+			want: nil,
+		},
+		{
+			name: "should return nil when l1 read depth is finalized",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				L1ReadDepth:                   "finalized",
+			},
+			want: nil,
+		},
+		{
+			name: "should return nil when rollup node verifier is given with a valid endpoint",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Verifier:                      "both",
+				RollupNodeRPCEndpoint:         "http://op-node.xyz.com",
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when verifier is invalid",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Verifier:                      "zk",
+			},
+			want: fmt.Errorf("faultdetector.verifier expected one of %s, received: 'zk'", []string{"proof", "rollup_node", "both"}),
+		},
+		{
+			name: "should return error when rollup node verifier is given without endpoint",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Verifier:                      "rollup_node",
+			},
+			want: fmt.Errorf("faultdetector.rollup_node_rpc_endpoint expected to match regex: `%s`, received: ''", providerEndpointRegex.String()),
+		},
+		{
+			name: "should return error when l1 read depth is invalid",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				L1ReadDepth:                   "pending",
+			},
+			want: fmt.Errorf("faultdetector.l1_read_depth expected one of [latest safe finalized] or a number of confirmations, received: 'pending'"),
+		},
+		{
+			name: "should return nil when multiple l2 provider endpoints are given with a valid quorum",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoints:                []string{"http://xyz.com", "https://abc.com", "wss://def.com"},
+				Quorum:                        2,
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when one of multiple l2 provider endpoints is invalid",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoints:                []string{"http://xyz.com", "ht://abc.com"},
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+			},
+			want: fmt.Errorf("faultdetector.l2_rpc_endpoints entry expected to match regex: `%s`, received: 'ht://abc.com'", providerEndpointRegex.String()),
+		},
+		{
+			name: "should return error when quorum is greater than the number of l2 provider endpoints",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoints:                []string{"http://xyz.com", "https://abc.com"},
+				Quorum:                        3,
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+			},
+			want: fmt.Errorf("faultdetector.quorum expected in range: 0 - 2, received: 3"),
+		},
+		{
+			name: "should return error when quorum is not a majority of the l2 provider endpoints",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoints:                []string{"http://xyz.com", "https://abc.com", "wss://def.com", "wss://ghi.com"},
+				Quorum:                        2,
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+			},
+			want: fmt.Errorf("faultdetector.quorum expected to be a majority of the 4 l2 rpc endpoints, at least 3, received: 2"),
+		},
+		{
+			name: "should return nil when checkpoint is enabled with a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Checkpoint: &Checkpoint{
+					Enable:               true,
+					Directory:            "./data",
+					ResumeFromCheckpoint: true,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return nil when checkpoint is disabled without a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Checkpoint: &Checkpoint{
+					Enable: false,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when checkpoint is enabled without a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Checkpoint: &Checkpoint{
+					Enable:    true,
+					Directory: " ",
+				},
+			},
+			want: fmt.Errorf("faultdetector.checkpoint.directory expected to be non-empty, received: ' '"),
+		},
+		{
+			name: "should return nil when fault history is enabled with a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				FaultHistory: &FaultHistory{
+					Enable:    true,
+					Directory: "./data",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when fault history is enabled without a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				FaultHistory: &FaultHistory{
+					Enable: true,
+				},
+			},
+			want: fmt.Errorf("faultdetector.fault_history.directory expected to be non-empty, received: ''"),
+		},
+		{
+			name: "should return nil when evidence is enabled with a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Evidence: &Evidence{
+					Enable:    true,
+					Directory: "./data/evidence",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when evidence is enabled without a directory",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Evidence: &Evidence{
+					Enable: true,
+				},
+			},
+			want: fmt.Errorf("faultdetector.evidence.directory expected to be non-empty, received: ''"),
+		},
+		{
+			name: "should return error when evidence is enabled with the rollup node verifier",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Verifier:                      "rollup_node",
+				RollupNodeRPCEndpoint:         "http://xyz.com",
+				Evidence: &Evidence{
+					Enable:    true,
+					Directory: "./data/evidence",
+				},
+			},
+			want: fmt.Errorf("faultdetector.evidence expected to be disabled with verifier: 'rollup_node'"),
+		},
+		{
+			name: "should return nil when proposer liveness is enabled with a valid interval multiplier",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				ProposerLiveness: &ProposerLiveness{
+					Enable:             true,
+					IntervalMultiplier: 1.5,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when proposer liveness is enabled with an invalid interval multiplier",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				ProposerLiveness: &ProposerLiveness{
+					Enable:             true,
+					IntervalMultiplier: 0.5,
+				},
+			},
+			want: fmt.Errorf("faultdetector.proposer_liveness.interval_multiplier expected to be greater than or equal to 1, received: 0.5"),
+		},
+		{
+			name: "should return nil when proposer check is enabled with a valid allowlist",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				ProposerCheck: &ProposerCheck{
+					Enable:    true,
+					Allowlist: []string{"0x473300df21D047806A082244b417f96b32f13A33"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when proposer check is enabled with an invalid allowlist entry",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				ProposerCheck: &ProposerCheck{
+					Enable:    true,
+					Allowlist: []string{"0x473300"},
+				},
+			},
+			want: fmt.Errorf("faultdetector.proposer_check.allowlist entry expected to match regex: `%s`, received: '0x473300'", addressRegex.String()),
+		},
+		{
+			name: "should return nil when oracle parameters check is enabled with a valid interval",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				OracleParametersCheck: &OracleParametersCheck{
+					Enable:   true,
+					Interval: 5 * time.Minute,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when oracle parameters check is enabled without an interval",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				OracleParametersCheck: &OracleParametersCheck{
+					Enable: true,
+				},
+			},
+			want: fmt.Errorf("faultdetector.oracle_parameters_check.interval expected to be greater than 0, received: 0s"),
+		},
+		{
+			name: "should return nil when finalization escalation is enabled with valid thresholds",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				FinalizationEscalation: &FinalizationEscalation{
+					Enable: true,
+					Thresholds: []*EscalationThreshold{
+						{Before: 24 * time.Hour, Severity: "warning"},
+						{Before: time.Hour, Severity: "critical", SlackChannelID: "C0123456789"},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when finalization escalation is enabled with invalid thresholds",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				FinalizationEscalation: &FinalizationEscalation{
+					Enable: true,
+					Thresholds: []*EscalationThreshold{
+						{Before: time.Hour, Severity: "warning"},
+						{Before: time.Hour, Severity: "", SlackChannelID: "#alerts"},
+					},
+				},
+			},
+			want: multierr.Combine(
+				fmt.Errorf("faultdetector.finalization_escalation.thresholds[].before expected to be unique, received: 1h0m0s"),
+				fmt.Errorf("faultdetector.finalization_escalation.thresholds[].severity expected to be non-empty, received: ''"),
+				fmt.Errorf("faultdetector.finalization_escalation.thresholds[].slack_channel_id expected to match regex: `%s`, received: '#alerts'", slackChannelID.String()),
+			),
+		},
+		{
+			name: "should return nil when scheduler intervals are valid",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Scheduler: &Scheduler{
+					ProposalInterval:       time.Hour,
+					BackoffInitialInterval: time.Second,
+					BackoffMaxInterval:     time.Minute,
+					BackoffMultiplier:      1.5,
+					BackoffJitter:          0.1,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when scheduler intervals are invalid",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				Scheduler: &Scheduler{
+					L2NodeBehindInterval:   -time.Second,
+					BackoffInitialInterval: time.Minute,
+					BackoffMaxInterval:     time.Second,
+					BackoffMultiplier:      0.5,
+					BackoffJitter:          2,
+				},
+			},
+			want: multierr.Combine(
+				fmt.Errorf("faultdetector.scheduler.l2_node_behind_interval expected to be non-negative, received: '-1s'"),
+				fmt.Errorf("faultdetector.scheduler.backoff_max_interval expected to be greater than or equal to faultdetector.scheduler.backoff_initial_interval, received: '1s'"),
+				fmt.Errorf("faultdetector.scheduler.backoff_multiplier expected to be greater than or equal to 1, received: 0.5"),
+				fmt.Errorf("faultdetector.scheduler.backoff_jitter expected in range: 0 - 1, received: 2"),
+			),
+		},
+		{
+			name: "should return nil when catch-up is enabled with valid parameters",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				CatchUp: &CatchUp{
+					Enable:     true,
+					Threshold:  10,
+					WindowSize: 50,
+					Workers:    5,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return error when catch-up is enabled with invalid parameters",
+			config: &FaultDetectorConfig{
+				L1RPCEndpoint:                 "http://xyz.com",
+				L2RPCEndpoint:                 "http://xyz.com",
+				StartBatchIndex:               100,
+				L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				CatchUp: &CatchUp{
+					Enable: true,
+				},
+			},
+			want: multierr.Combine(
+				fmt.Errorf("faultdetector.catch_up.threshold expected to be greater than 0, received: 0"),
+				fmt.Errorf("faultdetector.catch_up.window_size expected to be greater than 0, received: 0"),
+				fmt.Errorf("faultdetector.catch_up.workers expected to be greater than 0, received: 0"),
+			),
+		},
 	}
 
 	t.Parallel()
@@ -308,6 +769,84 @@ func TestValidate_FaultDetector(t *testing.T) {
 	}
 }
 
+func TestFaultDetectorConfig_GetQuorum(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *FaultDetectorConfig
+		want   uint
+	}{
+		{
+			name:   "should return 1 when a single l2 provider endpoint is given",
+			config: &FaultDetectorConfig{L2RPCEndpoint: "http://xyz.com"},
+			want:   1,
+		},
+		{
+			name:   "should return majority when quorum is not given",
+			config: &FaultDetectorConfig{L2RPCEndpoints: []string{"http://a.com", "http://b.com", "http://c.com", "http://d.com"}},
+			want:   3,
+		},
+		{
+			name:   "should return configured quorum",
+			config: &FaultDetectorConfig{L2RPCEndpoints: []string{"http://a.com", "http://b.com", "http://c.com"}, Quorum: 3},
+			want:   3,
+		},
+	}
+
+	t.Parallel()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.config.GetQuorum(), tc.want)
+		})
+	}
+}
+
+func TestValidateFaultDetectorConfigs(t *testing.T) {
+	validConfig := func(name string) *FaultDetectorConfig {
+		return &FaultDetectorConfig{
+			Name:                          name,
+			L1RPCEndpoint:                 "https://xyz.com",
+			L2RPCEndpoint:                 "https://xyz.com",
+			StartBatchIndex:               -1,
+			L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		configs []*FaultDetectorConfig
+		want    error
+	}{
+		{
+			name:    "should return nil when multiple chains with unique names are specified",
+			configs: []*FaultDetectorConfig{validConfig("lisk-sepolia"), validConfig("op-mainnet"), validConfig("")},
+			want:    nil,
+		},
+		{
+			name:    "should return error when no chain is specified",
+			configs: []*FaultDetectorConfig{},
+			want:    fmt.Errorf("faultdetector expected at least one chain entry, received: 0"),
+		},
+		{
+			name:    "should return error when chain names are not unique",
+			configs: []*FaultDetectorConfig{validConfig("op-mainnet"), validConfig("op-mainnet")},
+			want:    fmt.Errorf("faultdetector.name expected to be unique, received duplicate: 'op-mainnet'"),
+		},
+		{
+			name:    "should return error when chain name is invalid",
+			configs: []*FaultDetectorConfig{validConfig("op mainnet")},
+			want:    fmt.Errorf("faultdetector.name expected to match regex: `%s`, received: 'op mainnet'", chainNameRegex.String()),
+		},
+	}
+
+	t.Parallel()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateFaultDetectorConfigs(tc.configs)
+			assert.Equal(t, got, tc.want)
+		})
+	}
+}
+
 func TestFormatError(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -376,11 +915,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "http://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "http://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -405,11 +946,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "http://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "http://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -436,11 +979,13 @@ func TestValidate_Config(t *testing.T) {
 					"api/",
 					[]string{"v1", "version1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "http://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "http://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -476,11 +1021,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "htps://xyz.com",
-					L2RPCEndpoint:                 "http://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "htps://xyz.com",
+						L2RPCEndpoint:                 "http://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "0x0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -514,11 +1061,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "htps://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "htps://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -553,11 +1102,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "htps://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "htps://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
@@ -593,11 +1144,13 @@ func TestValidate_Config(t *testing.T) {
 					"/api",
 					[]string{"v1"},
 				},
-				&FaultDetectorConfig{
-					L1RPCEndpoint:                 "https://xyz.com",
-					L2RPCEndpoint:                 "htps://xyz.com",
-					StartBatchIndex:               100,
-					L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+				[]*FaultDetectorConfig{
+					{
+						L1RPCEndpoint:                 "https://xyz.com",
+						L2RPCEndpoint:                 "htps://xyz.com",
+						StartBatchIndex:               100,
+						L2OutputOracleContractAddress: "xx0000000000000000000000000000000000000000",
+					},
 				},
 				&Notification{
 					&SlackConfig{
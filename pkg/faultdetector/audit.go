@@ -0,0 +1,172 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Verdicts of the audited outputs.
+const (
+	VerdictOk       = "ok"
+	VerdictMismatch = "mismatch"
+	VerdictError    = "error"
+	VerdictSkipped  = "skipped"
+)
+
+// OutputAudit is the result of verifying a single output during an audit.
+type OutputAudit struct {
+	OutputIndex          uint64 `json:"outputIndex"`
+	L2BlockNumber        uint64 `json:"l2BlockNumber"`
+	L1Timestamp          uint64 `json:"l1Timestamp"`
+	ExpectedOutputRoot   string `json:"expectedOutputRoot"`
+	CalculatedOutputRoot string `json:"calculatedOutputRoot"`
+	Verdict              string `json:"verdict"`
+	Error                string `json:"error,omitempty"`
+}
+
+// Auditor verifies an arbitrary range of outputs with the same verification logic as the fault detector, without any live state.
+type Auditor struct {
+	fd      *FaultDetector
+	workers uint
+}
+
+// NewAuditor returns [Auditor] with the providers, oracle contract accessor and verifier initialized from the given configuration.
+// The outputs are verified concurrently with the given number of workers.
+func NewAuditor(ctx context.Context, logger log.Logger, faultDetectorConfig *config.FaultDetectorConfig, workers uint) (*Auditor, error) {
+	fd, err := newFaultDetector(ctx, logger, faultDetectorConfig, prometheus.NewRegistry())
+	if err != nil {
+		return nil, err
+	}
+
+	// Pin all the oracle reads of the audit to the same L1 block
+	if err := fd.pinL1Block(); err != nil {
+		return nil, err
+	}
+
+	return &Auditor{
+		fd:      fd,
+		workers: max(workers, 1),
+	}, nil
+}
+
+// AuditOutputs verifies every output with index from fromIndex to toIndex, both inclusive, and returns the results in the order of output index.
+// A failure to verify a single output is reported in its result and does not stop the audit.
+func (a *Auditor) AuditOutputs(fromIndex uint64, toIndex uint64) ([]*OutputAudit, error) {
+	if fromIndex > toIndex {
+		return nil, fmt.Errorf("invalid output index range: %d - %d", fromIndex, toIndex)
+	}
+
+	nextOutputIndex, err := a.fd.oracleContractAccessor.GetNextOutputIndex()
+	if err != nil {
+		a.fd.logger.Errorf("Failed to query next output index, error: %v.", err)
+		return nil, err
+	}
+	if toIndex >= encoding.MustConvertBigIntToUint64(nextOutputIndex) {
+		return nil, fmt.Errorf("output index %d is not yet proposed, next output index: %d", toIndex, nextOutputIndex)
+	}
+
+	audits := make([]*OutputAudit, toIndex-fromIndex+1)
+	indexes := make(chan uint64)
+	var wg sync.WaitGroup
+	for i := uint(0); i < a.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for outputIndex := range indexes {
+				audits[outputIndex-fromIndex] = a.auditOutput(outputIndex)
+			}
+		}()
+	}
+	for outputIndex := fromIndex; outputIndex <= toIndex; outputIndex++ {
+		indexes <- outputIndex
+	}
+	close(indexes)
+	wg.Wait()
+
+	return audits, nil
+}
+
+// auditOutput verifies a single output and converts the verification to its audit result.
+func (a *Auditor) auditOutput(outputIndex uint64) *OutputAudit {
+	verification, err := a.fd.verifyOutput(outputIndex)
+	if err != nil {
+		return &OutputAudit{
+			OutputIndex: outputIndex,
+			Verdict:     VerdictError,
+			Error:       err.Error(),
+		}
+	}
+
+	verdict := VerdictOk
+	if verification.unmonitored {
+		verdict = VerdictSkipped
+	} else if !verification.isMatched() {
+		verdict = VerdictMismatch
+	}
+	a.fd.logger.Infof("Audited output with index %d --> %s.", outputIndex, verdict)
+
+	return &OutputAudit{
+		OutputIndex:          verification.outputIndex,
+		L2BlockNumber:        verification.l2BlockNumber,
+		L1Timestamp:          verification.l1Timestamp,
+		ExpectedOutputRoot:   verification.expectedOutputRoot,
+		CalculatedOutputRoot: verification.calculatedOutputRoot,
+		Verdict:              verdict,
+	}
+}
+
+// FindOutputIndexRange returns the range of the output indexes whose L2 block number is within the given L2 block range, both inclusive.
+func (a *Auditor) FindOutputIndexRange(fromBlock uint64, toBlock uint64) (uint64, uint64, error) {
+	if fromBlock > toBlock {
+		return 0, 0, fmt.Errorf("invalid L2 block range: %d - %d", fromBlock, toBlock)
+	}
+
+	nextOutputIndexBigInt, err := a.fd.oracleContractAccessor.GetNextOutputIndex()
+	if err != nil {
+		a.fd.logger.Errorf("Failed to query next output index, error: %v.", err)
+		return 0, 0, err
+	}
+	nextOutputIndex := encoding.MustConvertBigIntToUint64(nextOutputIndexBigInt)
+
+	fromIndex, err := a.findFirstOutputIndexAfter(fromBlock, nextOutputIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+	toIndexExclusive, err := a.findFirstOutputIndexAfter(toBlock+1, nextOutputIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromIndex >= toIndexExclusive {
+		return 0, 0, fmt.Errorf("no output proposed within L2 block range: %d - %d", fromBlock, toBlock)
+	}
+
+	return fromIndex, toIndexExclusive - 1, nil
+}
+
+// findFirstOutputIndexAfter performs a binary search for the first output whose L2 block number is greater than or equal to the given L2 block number.
+// It returns the next output index when no such output is proposed yet.
+func (a *Auditor) findFirstOutputIndexAfter(l2BlockNumber uint64, nextOutputIndex uint64) (uint64, error) {
+	lo, hi := uint64(0), nextOutputIndex
+	for lo < hi {
+		mid := (lo + hi) / 2
+		outputData, err := a.fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(mid))
+		if err != nil {
+			a.fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", mid, err)
+			return 0, err
+		}
+
+		if outputData.L2BlockNumber < l2BlockNumber {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo, nil
+}
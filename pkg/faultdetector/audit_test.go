@@ -0,0 +1,197 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutputVerifier struct {
+	mock.Mock
+}
+
+func (m *mockOutputVerifier) computeOutputRoot(outputIndex uint64, l2BlockNumber uint64) (*providerOutput, *nodeInconsistency, error) {
+	called := m.MethodCalled("computeOutputRoot", outputIndex, l2BlockNumber)
+	return called.Get(0).(*providerOutput), nil, called.Error(1)
+}
+
+// newMockAuditor returns an auditor over outputs proposed every 100 L2 blocks, starting from block 100.
+// The local view matches every output root except the ones with the mismatched indexes, the outputs with the unmonitored indexes are not verified.
+func newMockAuditor(nextOutputIndex uint64, mismatched map[uint64]bool, failed map[uint64]bool, unmonitored map[uint64]bool) *Auditor {
+	logger, _ := log.NewDefaultProductionLogger()
+	oracle := new(mockOracleAccessor)
+	verifier := new(mockOutputVerifier)
+	oracle.On("GetNextOutputIndex").Return(new(big.Int).SetUint64(nextOutputIndex), nil)
+
+	for index := uint64(0); index < nextOutputIndex; index++ {
+		outputIndex := index
+		l2BlockNumber := (outputIndex + 1) * 100
+		outputRoot := randHash().String()
+		if unmonitored[outputIndex] {
+			oracle.On("GetL2Output", mock.MatchedBy(func(i *big.Int) bool { return i.Uint64() == outputIndex })).Return(chain.L2Output{
+				L1Timestamp:   1000 + outputIndex,
+				L2OutputIndex: outputIndex,
+				Unmonitored:   true,
+			}, nil)
+			continue
+		}
+		oracle.On("GetL2Output", mock.MatchedBy(func(i *big.Int) bool { return i.Uint64() == outputIndex })).Return(chain.L2Output{
+			OutputRoot:    outputRoot,
+			L2BlockNumber: l2BlockNumber,
+			L1Timestamp:   1000 + outputIndex,
+		}, nil)
+
+		calculatedOutputRoot := outputRoot
+		if mismatched[outputIndex] {
+			calculatedOutputRoot = randHash().String()
+		}
+		var err error
+		if failed[outputIndex] {
+			err = fmt.Errorf("Failed to compute output root")
+		}
+		verifier.On("computeOutputRoot", outputIndex, l2BlockNumber).Return(&providerOutput{outputRoot: calculatedOutputRoot}, err)
+	}
+
+	return &Auditor{
+		fd: &FaultDetector{
+			ctx:                    context.Background(),
+			logger:                 logger,
+			metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+			oracleContractAccessor: oracle,
+			verifier:               verifier,
+		},
+		workers: 3,
+	}
+}
+
+func TestAuditor_AuditOutputs(t *testing.T) {
+	tests := []struct {
+		name             string
+		fromIndex        uint64
+		toIndex          uint64
+		expectedVerdicts []string
+		expectedErr      bool
+	}{
+		{
+			name:             "should return the verdict of every output in the range in order",
+			fromIndex:        2,
+			toIndex:          7,
+			expectedVerdicts: []string{VerdictOk, VerdictMismatch, VerdictOk, VerdictError, VerdictOk, VerdictSkipped},
+		},
+		{
+			name:             "should audit a single output",
+			fromIndex:        3,
+			toIndex:          3,
+			expectedVerdicts: []string{VerdictMismatch},
+		},
+		{
+			name:        "should return error when the range is inverted",
+			fromIndex:   6,
+			toIndex:     2,
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the range includes outputs not yet proposed",
+			fromIndex:   8,
+			toIndex:     10,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditor := newMockAuditor(10, map[uint64]bool{3: true}, map[uint64]bool{5: true}, map[uint64]bool{7: true})
+
+			audits, err := auditor.AuditOutputs(test.fromIndex, test.toIndex)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, audits, len(test.expectedVerdicts))
+			for i, audit := range audits {
+				outputIndex := test.fromIndex + uint64(i)
+				require.Equal(t, outputIndex, audit.OutputIndex)
+				require.Equal(t, test.expectedVerdicts[i], audit.Verdict)
+				if audit.Verdict == VerdictError {
+					require.NotEmpty(t, audit.Error)
+					continue
+				}
+				require.Equal(t, 1000+outputIndex, audit.L1Timestamp)
+				if audit.Verdict == VerdictSkipped {
+					continue
+				}
+				require.Equal(t, (outputIndex+1)*100, audit.L2BlockNumber)
+			}
+		})
+	}
+}
+
+func TestAuditor_FindOutputIndexRange(t *testing.T) {
+	tests := []struct {
+		name              string
+		fromBlock         uint64
+		toBlock           uint64
+		expectedFromIndex uint64
+		expectedToIndex   uint64
+		expectedErr       bool
+	}{
+		{
+			name:              "should return the outputs within the block range on output boundaries",
+			fromBlock:         300,
+			toBlock:           500,
+			expectedFromIndex: 2,
+			expectedToIndex:   4,
+		},
+		{
+			name:              "should return the outputs within the block range between output boundaries",
+			fromBlock:         250,
+			toBlock:           599,
+			expectedFromIndex: 2,
+			expectedToIndex:   4,
+		},
+		{
+			name:              "should return the proposed outputs when the block range exceeds the latest output",
+			fromBlock:         0,
+			toBlock:           5000,
+			expectedFromIndex: 0,
+			expectedToIndex:   9,
+		},
+		{
+			name:        "should return error when no output is proposed within the block range",
+			fromBlock:   310,
+			toBlock:     390,
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the block range is inverted",
+			fromBlock:   500,
+			toBlock:     300,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditor := newMockAuditor(10, nil, nil, nil)
+
+			fromIndex, toIndex, err := auditor.FindOutputIndexRange(test.fromBlock, test.toBlock)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expectedFromIndex, fromIndex)
+			require.Equal(t, test.expectedToIndex, toIndex)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package faultdetector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const checkpointFilePermission = 0o600
+
+// DivergedOutput holds the evidence of an output whose state root does not match the local view.
+type DivergedOutput struct {
+	OutputIndex          uint64    `json:"outputIndex"`
+	L2BlockNumber        uint64    `json:"l2BlockNumber"`
+	L1Timestamp          uint64    `json:"l1Timestamp,omitempty"`
+	ExpectedOutputRoot   string    `json:"expectedOutputRoot"`
+	CalculatedOutputRoot string    `json:"calculatedOutputRoot"`
+	FinalizationTime     time.Time `json:"finalizationTime"`
+}
+
+// Checkpoint holds the progress of the fault detector that is persisted after every checked batch.
+// LastVerifiedOutputIndex is nil when no output has been verified yet, e.g. after a rewind to the first output.
+type Checkpoint struct {
+	ChainID                 uint64          `json:"chainId"`
+	LastVerifiedOutputIndex *uint64         `json:"lastVerifiedOutputIndex,omitempty"`
+	Diverged                bool            `json:"diverged"`
+	DivergedOutput          *DivergedOutput `json:"divergedOutput,omitempty"`
+	// DivergedOutputs holds all the diverged outputs when the scan continues past the faults.
+	DivergedOutputs []*DivergedOutput `json:"divergedOutputs,omitempty"`
+	UpdatedAt       time.Time         `json:"updatedAt"`
+}
+
+// ResumeOutputIndex returns the output index the fault detector should check first when resuming from the checkpoint.
+// When the scan continued past the faults, it resumes after the last checked output.
+// It returns false when the checkpoint holds no verified output to resume after.
+func (c *Checkpoint) ResumeOutputIndex() (uint64, bool) {
+	if c.Diverged && c.DivergedOutput != nil && len(c.DivergedOutputs) == 0 {
+		return c.DivergedOutput.OutputIndex, true
+	}
+	if c.LastVerifiedOutputIndex == nil {
+		return 0, false
+	}
+	return *c.LastVerifiedOutputIndex + 1, true
+}
+
+// GetDivergedOutputs returns the diverged outputs reported by the checkpoint.
+func (c *Checkpoint) GetDivergedOutputs() []*DivergedOutput {
+	if len(c.DivergedOutputs) > 0 {
+		return c.DivergedOutputs
+	}
+	if c.Diverged && c.DivergedOutput != nil {
+		return []*DivergedOutput{c.DivergedOutput}
+	}
+	return nil
+}
+
+// CheckpointStore persists and restores the fault detector progress.
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint or nil when no checkpoint has been saved yet.
+	Load() (*Checkpoint, error)
+	Save(checkpoint *Checkpoint) error
+}
+
+// FileCheckpointStore is a [CheckpointStore] that keeps the checkpoint as a JSON file on the local disk.
+type FileCheckpointStore struct {
+	filePath string
+}
+
+// NewFileCheckpointStore returns [FileCheckpointStore] storing the checkpoint for the given chainID in the given directory.
+func NewFileCheckpointStore(directory string, chainID uint64) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory %s: %w", directory, err)
+	}
+
+	return &FileCheckpointStore{
+		filePath: filepath.Join(directory, fmt.Sprintf("checkpoint_%d.json", chainID)),
+	}, nil
+}
+
+// Load reads the checkpoint from the file, returns nil when the file does not exist.
+func (s *FileCheckpointStore) Load() (*Checkpoint, error) {
+	content, err := os.ReadFile(s.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(content, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint file %s: %w", s.filePath, err)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file and renames it, so that a crash never leaves a partially written checkpoint.
+func (s *FileCheckpointStore) Save(checkpoint *Checkpoint) error {
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, content, checkpointFilePermission); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilePath, s.filePath)
+}
@@ -0,0 +1,149 @@
+package faultdetector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uint64Ptr(value uint64) *uint64 {
+	return &value
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	const chainID uint64 = 4202
+
+	t.Run("should return nil when no checkpoint was saved", func(t *testing.T) {
+		store, err := NewFileCheckpointStore(t.TempDir(), chainID)
+		require.NoError(t, err)
+
+		checkpoint, err := store.Load()
+		require.NoError(t, err)
+		require.Nil(t, checkpoint)
+	})
+
+	t.Run("should load the last saved checkpoint", func(t *testing.T) {
+		store, err := NewFileCheckpointStore(t.TempDir(), chainID)
+		require.NoError(t, err)
+
+		first := &Checkpoint{
+			ChainID:                 chainID,
+			LastVerifiedOutputIndex: uint64Ptr(10),
+			UpdatedAt:               time.Unix(1000, 0).UTC(),
+		}
+		second := &Checkpoint{
+			ChainID:                 chainID,
+			LastVerifiedOutputIndex: uint64Ptr(10),
+			Diverged:                true,
+			DivergedOutput: &DivergedOutput{
+				OutputIndex:          11,
+				L2BlockNumber:        1800,
+				ExpectedOutputRoot:   randHash().String(),
+				CalculatedOutputRoot: randHash().String(),
+				FinalizationTime:     time.Unix(2000, 0).UTC(),
+			},
+			UpdatedAt: time.Unix(1001, 0).UTC(),
+		}
+		require.NoError(t, store.Save(first))
+		require.NoError(t, store.Save(second))
+
+		checkpoint, err := store.Load()
+		require.NoError(t, err)
+		require.Equal(t, second, checkpoint)
+	})
+
+	t.Run("should load the checkpoint without a verified output", func(t *testing.T) {
+		store, err := NewFileCheckpointStore(t.TempDir(), chainID)
+		require.NoError(t, err)
+		require.NoError(t, store.Save(&Checkpoint{ChainID: chainID, UpdatedAt: time.Unix(1000, 0).UTC()}))
+
+		checkpoint, err := store.Load()
+		require.NoError(t, err)
+		require.Nil(t, checkpoint.LastVerifiedOutputIndex)
+	})
+
+	t.Run("should load the last verified output index of the first output", func(t *testing.T) {
+		var checkpoint Checkpoint
+		require.NoError(t, json.Unmarshal([]byte(`{"chainId":4202,"lastVerifiedOutputIndex":0,"diverged":false}`), &checkpoint))
+		require.Equal(t, uint64Ptr(0), checkpoint.LastVerifiedOutputIndex)
+	})
+
+	t.Run("should return error when checkpoint file is corrupted", func(t *testing.T) {
+		directory := t.TempDir()
+		store, err := NewFileCheckpointStore(directory, chainID)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(directory, "checkpoint_4202.json"), []byte("{"), 0o600))
+
+		checkpoint, err := store.Load()
+		require.Error(t, err)
+		require.Nil(t, checkpoint)
+	})
+}
+
+func TestCheckpoint_ResumeOutputIndex(t *testing.T) {
+	tests := []struct {
+		name            string
+		checkpoint      *Checkpoint
+		expected        uint64
+		expectedResumed bool
+	}{
+		{
+			name:            "should resume after the last verified output index",
+			checkpoint:      &Checkpoint{LastVerifiedOutputIndex: uint64Ptr(10)},
+			expected:        11,
+			expectedResumed: true,
+		},
+		{
+			name:            "should resume after the first output when it is the last verified output",
+			checkpoint:      &Checkpoint{LastVerifiedOutputIndex: uint64Ptr(0)},
+			expected:        1,
+			expectedResumed: true,
+		},
+		{
+			name:       "should not resume when no output was verified",
+			checkpoint: &Checkpoint{},
+		},
+		{
+			name: "should resume at the diverged output index when no output was verified",
+			checkpoint: &Checkpoint{
+				Diverged:       true,
+				DivergedOutput: &DivergedOutput{OutputIndex: 0},
+			},
+			expected:        0,
+			expectedResumed: true,
+		},
+		{
+			name: "should resume at the diverged output index",
+			checkpoint: &Checkpoint{
+				LastVerifiedOutputIndex: uint64Ptr(10),
+				Diverged:                true,
+				DivergedOutput:          &DivergedOutput{OutputIndex: 11},
+			},
+			expected:        11,
+			expectedResumed: true,
+		},
+		{
+			name: "should resume after the last checked output index when the scan continued past the faults",
+			checkpoint: &Checkpoint{
+				LastVerifiedOutputIndex: uint64Ptr(13),
+				Diverged:                true,
+				DivergedOutput:          &DivergedOutput{OutputIndex: 11},
+				DivergedOutputs:         []*DivergedOutput{{OutputIndex: 11}, {OutputIndex: 13}},
+			},
+			expected:        14,
+			expectedResumed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputIndex, resumed := test.checkpoint.ResumeOutputIndex()
+			require.Equal(t, test.expected, outputIndex)
+			require.Equal(t, test.expectedResumed, resumed)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package faultdetector
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkDeletedOutputs detects the outputs deleted from the oracle, i.e. when the next output index decreases due to an `OutputsDeleted` event.
+// The current output index is rewound to the first deleted output, the diverged output is only cleared when it was deleted.
+// A decrease not caused by an `OutputsDeleted` event is left to be detected as an L1 reorg.
+func (fd *FaultDetector) checkDeletedOutputs(nextOutputIndex uint64) error {
+	prevNextOutputIndex := fd.lastNextOutputIndex
+	if nextOutputIndex < prevNextOutputIndex {
+		deleted, err := fd.isOutputsDeleted(nextOutputIndex)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			fd.rewindDeletedOutputs(prevNextOutputIndex, nextOutputIndex)
+		}
+	}
+
+	fd.lastNextOutputIndex = nextOutputIndex
+	if fd.pinnedL1Block != nil {
+		fd.lastNextOutputL1Block = fd.pinnedL1Block.Number.Uint64()
+	}
+	return nil
+}
+
+// isOutputsDeleted returns true when the oracle emitted an `OutputsDeleted` event leading to the given next output index since the last check.
+// Without the event logs, the deletion can not be told apart from an L1 reorg and any decrease is considered a deletion.
+func (fd *FaultDetector) isOutputsDeleted(nextOutputIndex uint64) (bool, error) {
+	filterer, ok := fd.oracleContractAccessor.(OutputsDeletedFilterer)
+	if !ok || fd.pinnedL1Block == nil {
+		return true, nil
+	}
+
+	toBlock := fd.pinnedL1Block.Number.Uint64()
+	fromBlock := min(fd.lastNextOutputL1Block, toBlock)
+	deletions, err := filterer.GetOutputsDeleted(fd.ctx, fromBlock, toBlock)
+	if err != nil {
+		fd.logger.Errorf("Failed to fetch deleted outputs within L1 blocks with height from %d to %d, error: %v.", fromBlock, toBlock, err)
+		fd.metrics.apiConnectionFailure.Inc()
+		return false, err
+	}
+
+	for _, deletion := range deletions {
+		if deletion.NewNextOutputIndex == nextOutputIndex {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rewindDeletedOutputs rewinds the fault detector state after the outputs with index from newNextOutputIndex up to prevNextOutputIndex were deleted.
+func (fd *FaultDetector) rewindDeletedOutputs(prevNextOutputIndex uint64, newNextOutputIndex uint64) {
+	faultyOutputDeleted := len(fd.removeDivergedOutputs(newNextOutputIndex, math.MaxUint64)) > 0
+	fd.resolveFaults(newNextOutputIndex, prevNextOutputIndex-1, FaultResolutionDeleted)
+
+	if fd.currentOutputIndex > newNextOutputIndex {
+		fd.logger.Infof("Rewinding current output index from %d to %d.", fd.currentOutputIndex, newNextOutputIndex)
+		fd.currentOutputIndex = newNextOutputIndex
+		fd.rewindLastVerifiedIndex(newNextOutputIndex)
+	}
+	if fd.lastVerifiedOutput != nil && fd.lastVerifiedOutput.outputIndex >= newNextOutputIndex {
+		fd.lastVerifiedOutput = nil
+	}
+	fd.saveCheckpoint()
+
+	fd.metrics.deletedOutputs.Add(float64(prevNextOutputIndex - newNextOutputIndex))
+	fd.logger.Warningf("Outputs with index from %d to %d were deleted from the oracle, faulty output deleted: %t.", newNextOutputIndex, prevNextOutputIndex-1, faultyOutputDeleted)
+	fd.notify(fmt.Sprintf("*Outputs deleted*, outputs were deleted from the oracle:\nDeletedOutputIndexes: %d - %d\nCurrentOutputIndex: %d\nFaultyOutputDeleted: %t", newNextOutputIndex, prevNextOutputIndex-1, fd.currentOutputIndex, faultyOutputDeleted))
+}
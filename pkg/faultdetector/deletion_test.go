@@ -0,0 +1,122 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutputsDeletedFilterer struct {
+	mockL1BlockPinner
+}
+
+func (o *mockOutputsDeletedFilterer) GetOutputsDeleted(ctx context.Context, fromBlock uint64, toBlock uint64) ([]chain.OutputsDeleted, error) {
+	called := o.MethodCalled("GetOutputsDeleted", ctx, fromBlock, toBlock)
+	return called.Get(0).([]chain.OutputsDeleted), called.Error(1)
+}
+
+func TestCheckDeletedOutputs(t *testing.T) {
+	tests := []struct {
+		name                       string
+		nextOutputIndex            uint64
+		deletions                  []chain.OutputsDeleted
+		divergedOutput             *DivergedOutput
+		expectedDeletedOutputs     float64
+		expectedCurrentOutputIndex uint64
+		expectedLastVerifiedIndex  *uint64
+		expectedDiverged           bool
+	}{
+		{
+			name:                       "should not rewind when the next output index increases",
+			nextOutputIndex:            13,
+			expectedDeletedOutputs:     0,
+			expectedCurrentOutputIndex: 11,
+			expectedLastVerifiedIndex:  uint64Ptr(10),
+		},
+		{
+			name:                       "should rewind when the outputs are deleted",
+			nextOutputIndex:            8,
+			deletions:                  []chain.OutputsDeleted{{PrevNextOutputIndex: 12, NewNextOutputIndex: 8, L1BlockNumber: 100}},
+			expectedDeletedOutputs:     4,
+			expectedCurrentOutputIndex: 8,
+			expectedLastVerifiedIndex:  uint64Ptr(7),
+		},
+		{
+			name:                       "should mark no output as verified when all the outputs are deleted",
+			nextOutputIndex:            0,
+			deletions:                  []chain.OutputsDeleted{{PrevNextOutputIndex: 12, NewNextOutputIndex: 0, L1BlockNumber: 100}},
+			expectedDeletedOutputs:     12,
+			expectedCurrentOutputIndex: 0,
+		},
+		{
+			name:                       "should clear the diverged output when it is deleted",
+			nextOutputIndex:            11,
+			deletions:                  []chain.OutputsDeleted{{PrevNextOutputIndex: 12, NewNextOutputIndex: 11, L1BlockNumber: 100}},
+			divergedOutput:             &DivergedOutput{OutputIndex: 11},
+			expectedDeletedOutputs:     1,
+			expectedCurrentOutputIndex: 11,
+			expectedLastVerifiedIndex:  uint64Ptr(10),
+			expectedDiverged:           false,
+		},
+		{
+			name:                       "should retain the diverged output when it is not deleted",
+			nextOutputIndex:            7,
+			deletions:                  []chain.OutputsDeleted{{PrevNextOutputIndex: 12, NewNextOutputIndex: 7, L1BlockNumber: 100}},
+			divergedOutput:             &DivergedOutput{OutputIndex: 6},
+			expectedDeletedOutputs:     5,
+			expectedCurrentOutputIndex: 7,
+			expectedLastVerifiedIndex:  uint64Ptr(6),
+			expectedDiverged:           true,
+		},
+		{
+			name:                       "should not rewind when the decrease is not caused by a deletion",
+			nextOutputIndex:            10,
+			deletions:                  []chain.OutputsDeleted{},
+			expectedDeletedOutputs:     0,
+			expectedCurrentOutputIndex: 11,
+			expectedLastVerifiedIndex:  uint64Ptr(10),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			oracle := new(mockOutputsDeletedFilterer)
+			oracle.On("PinL1Block", mock.Anything).Return(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}, nil)
+			oracle.On("GetOutputsDeleted", mock.Anything, uint64(90), uint64(100)).Return(test.deletions, nil)
+
+			fd := &FaultDetector{
+				ctx:                    context.Background(),
+				logger:                 logger,
+				metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				oracleContractAccessor: oracle,
+				currentOutputIndex:     11,
+				lastVerifiedIndex:      uint64Ptr(10),
+				lastNextOutputIndex:    12,
+				lastNextOutputL1Block:  90,
+				mutex:                  new(sync.RWMutex),
+			}
+			if test.divergedOutput != nil {
+				fd.addDivergedOutput(test.divergedOutput)
+			}
+
+			require.NoError(t, fd.pinL1Block())
+			require.NoError(t, fd.checkDeletedOutputs(test.nextOutputIndex))
+			require.Equal(t, test.expectedDeletedOutputs, testutil.ToFloat64(fd.metrics.deletedOutputs))
+			require.Equal(t, test.expectedCurrentOutputIndex, fd.currentOutputIndex)
+			require.Equal(t, test.expectedLastVerifiedIndex, fd.lastVerifiedIndex)
+			require.Equal(t, test.expectedDiverged, fd.diverged)
+			require.Equal(t, test.nextOutputIndex, fd.lastNextOutputIndex)
+			require.Equal(t, uint64(100), fd.lastNextOutputL1Block)
+		})
+	}
+}
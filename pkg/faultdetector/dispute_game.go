@@ -0,0 +1,108 @@
+package faultdetector
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+)
+
+const disputeGameStatusCheckInterval = 60 * time.Second
+
+// trackedDisputeGame holds a verified dispute game whose status is tracked until it is resolved.
+type trackedDisputeGame struct {
+	index                uint64
+	validRootClaim       bool
+	rootClaim            string
+	calculatedOutputRoot string
+	gameAddress          string
+}
+
+// isInvalidResolution returns true when the game is resolved in favor of an invalid root claim or against a valid one.
+func (g *trackedDisputeGame) isInvalidResolution(status uint8) bool {
+	return (!g.validRootClaim && status == chain.GameStatusDefenderWins) || (g.validRootClaim && status == chain.GameStatusChallengerWins)
+}
+
+// trackDisputeGame starts tracking the status of the dispute game with the verified root claim, when outputs are read from dispute games.
+// Games already tracked or resolved are skipped, as the same output may be verified again, e.g. while it diverges.
+func (fd *FaultDetector) trackDisputeGame(verification *outputVerification) {
+	if _, ok := fd.oracleContractAccessor.(DisputeGameAccessor); !ok {
+		return
+	}
+	if _, ok := fd.trackedDisputeGames[verification.outputIndex]; ok || fd.resolvedDisputeGames[verification.outputIndex] {
+		return
+	}
+
+	if fd.trackedDisputeGames == nil {
+		fd.trackedDisputeGames = make(map[uint64]*trackedDisputeGame)
+	}
+	fd.trackedDisputeGames[verification.outputIndex] = &trackedDisputeGame{
+		index:                verification.outputIndex,
+		validRootClaim:       verification.isMatched(),
+		rootClaim:            verification.expectedOutputRoot,
+		calculatedOutputRoot: verification.calculatedOutputRoot,
+	}
+}
+
+// checkDisputeGames queries the status of the tracked dispute games, at most once every [disputeGameStatusCheckInterval].
+// A critical notification is sent when a game is resolved in favor of an invalid root claim or against a valid one.
+// The divergence of a game with an invalid root claim is cleared once the game is resolved in favor of the challenger.
+func (fd *FaultDetector) checkDisputeGames() {
+	disputeGameAccessor, ok := fd.oracleContractAccessor.(DisputeGameAccessor)
+	if !ok || len(fd.trackedDisputeGames) == 0 || time.Since(fd.lastDisputeGamesCheck) < disputeGameStatusCheckInterval {
+		return
+	}
+	fd.lastDisputeGamesCheck = time.Now()
+
+	for index, game := range fd.trackedDisputeGames {
+		disputeGame, err := disputeGameAccessor.GetDisputeGame(encoding.MustConvertUint64ToBigInt(index))
+		if err != nil {
+			fd.logger.Errorf("Failed to query status of the dispute game with index: %d, error: %v.", index, err)
+			fd.metrics.apiConnectionFailure.Inc()
+			continue
+		}
+
+		game.gameAddress = disputeGame.Address.Hex()
+		gameIndexLabel := strconv.FormatUint(index, 10)
+		if disputeGame.Status == chain.GameStatusInProgress {
+			fd.metrics.disputeGameStatus.WithLabelValues(gameIndexLabel, game.gameAddress).Set(float64(disputeGame.Status))
+			continue
+		}
+
+		// Stop tracking the resolved game, its outcome is reported by the resolution metrics
+		fd.metrics.disputeGameStatus.DeleteLabelValues(gameIndexLabel, game.gameAddress)
+		delete(fd.trackedDisputeGames, index)
+		if fd.resolvedDisputeGames == nil {
+			fd.resolvedDisputeGames = make(map[uint64]bool)
+		}
+		fd.resolvedDisputeGames[index] = true
+
+		claimLabel := "valid"
+		if !game.validRootClaim {
+			claimLabel = "invalid"
+		}
+		fd.metrics.disputeGamesResolved.WithLabelValues(chain.GameStatusToString(disputeGame.Status), claimLabel).Inc()
+
+		if !game.isInvalidResolution(disputeGame.Status) {
+			fd.logger.Infof("Dispute game with index %d and address %s resolved with status %s as expected.", index, game.gameAddress, chain.GameStatusToString(disputeGame.Status))
+			if !game.validRootClaim {
+				fd.clearChallengedOutput(index)
+			}
+			continue
+		}
+
+		fd.metrics.disputeGameInvalidResolution.Inc()
+		fd.logger.Errorf("Dispute game with index %d and address %s with %s root claim resolved with status %s, rootClaim: %s, calculatedOutputRoot: %s.", index, game.gameAddress, claimLabel, chain.GameStatusToString(disputeGame.Status), game.rootClaim, game.calculatedOutputRoot)
+		fd.notify(fmt.Sprintf("*CRITICAL: Dispute game resolved incorrectly*, game with %s root claim resolved as %s:\ngameIndex: %d\ngameAddress: %s\nRootClaim: %s\nCalculatedOutputRoot: %s", claimLabel, chain.GameStatusToString(disputeGame.Status), index, game.gameAddress, game.rootClaim, game.calculatedOutputRoot))
+	}
+}
+
+// clearChallengedOutput clears the diverged output of the dispute game resolved in favor of the challenger, as its invalid root claim can no longer be finalized.
+// The scan has already moved past the output, the dispute games are verified independently of each other.
+func (fd *FaultDetector) clearChallengedOutput(outputIndex uint64) {
+	fd.removeDivergedOutputs(outputIndex, outputIndex)
+	fd.saveCheckpoint()
+	fd.resolveFaults(outputIndex, outputIndex, FaultResolutionChallenged)
+}
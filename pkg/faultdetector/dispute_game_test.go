@@ -0,0 +1,206 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/LiskHQ/op-fault-detector/pkg/utils/notification"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDisputeGameAccessor struct {
+	mockOracleAccessor
+}
+
+func (o *mockDisputeGameAccessor) GetDisputeGame(index *big.Int) (chain.DisputeGame, error) {
+	called := o.MethodCalled("GetDisputeGame", index)
+	return called.Get(0).(chain.DisputeGame), called.Error(1)
+}
+
+func TestCheckDisputeGames(t *testing.T) {
+	gameAddress := common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1")
+
+	tests := []struct {
+		name                      string
+		validRootClaim            bool
+		disputeGame               chain.DisputeGame
+		disputeGameErr            error
+		expectedTracked           bool
+		expectedInvalidResolution float64
+	}{
+		{
+			name:                      "should keep tracking the game when it is in progress",
+			validRootClaim:            true,
+			disputeGame:               chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusInProgress},
+			expectedTracked:           true,
+			expectedInvalidResolution: 0,
+		},
+		{
+			name:                      "should keep tracking the game when status query fails",
+			validRootClaim:            true,
+			disputeGame:               chain.DisputeGame{},
+			disputeGameErr:            fmt.Errorf("Failed to get dispute game"),
+			expectedTracked:           true,
+			expectedInvalidResolution: 0,
+		},
+		{
+			name:                      "should stop tracking the game with valid root claim resolved in favor of the defender",
+			validRootClaim:            true,
+			disputeGame:               chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusDefenderWins},
+			expectedTracked:           false,
+			expectedInvalidResolution: 0,
+		},
+		{
+			name:                      "should report the game with invalid root claim resolved in favor of the defender",
+			validRootClaim:            false,
+			disputeGame:               chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusDefenderWins},
+			expectedTracked:           false,
+			expectedInvalidResolution: 1,
+		},
+		{
+			name:                      "should report the game with valid root claim resolved in favor of the challenger",
+			validRootClaim:            true,
+			disputeGame:               chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusChallengerWins},
+			expectedTracked:           false,
+			expectedInvalidResolution: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			oracle := new(mockDisputeGameAccessor)
+			oracle.On("GetDisputeGame", big.NewInt(5)).Return(test.disputeGame, test.disputeGameErr)
+
+			calculatedOutputRoot := randHash().String()
+			rootClaim := calculatedOutputRoot
+			if !test.validRootClaim {
+				rootClaim = randHash().String()
+			}
+
+			fd := &FaultDetector{
+				logger:                 logger,
+				oracleContractAccessor: oracle,
+				metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+			}
+			fd.trackDisputeGame(&outputVerification{
+				outputIndex:          5,
+				expectedOutputRoot:   rootClaim,
+				calculatedOutputRoot: calculatedOutputRoot,
+			})
+			fd.checkDisputeGames()
+
+			_, isTracked := fd.trackedDisputeGames[5]
+			require.Equal(t, test.expectedTracked, isTracked)
+			require.Equal(t, test.expectedInvalidResolution, testutil.ToFloat64(fd.metrics.disputeGameInvalidResolution))
+		})
+	}
+}
+
+func TestCommitVerification_ResolvedDisputeGame(t *testing.T) {
+	gameAddress := common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1")
+	slackClient := new(mockSlackClient)
+	slackClient.On("PostMessageContext", mock.Anything).Return("", "1234569.1000", nil)
+	oracle := new(mockDisputeGameAccessor)
+	oracle.On("GetDisputeGame", big.NewInt(5)).Return(chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusDefenderWins}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:                 logger,
+		oracleContractAccessor: oracle,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		currentOutputIndex:     5,
+		notification:           notification.GetNotification(context.Background(), logger, slackClient, &config.Notification{Slack: &config.SlackConfig{ChannelID: "Default"}}),
+	}
+	verification := &outputVerification{outputIndex: 5, expectedOutputRoot: "0x01", calculatedOutputRoot: "0x02", finalizationTime: time.Now().Add(time.Hour)}
+
+	// The game with the invalid root claim is resolved in favor of the defender
+	fd.commitVerification(verification)
+	fd.checkDisputeGames()
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.disputeGameInvalidResolution))
+	slackClient.AssertNumberOfCalls(t, "PostMessageContext", 2)
+
+	// The diverged output is verified again, without tracking its resolved game again
+	fd.commitVerification(verification)
+	require.Empty(t, fd.trackedDisputeGames)
+	fd.lastDisputeGamesCheck = time.Time{}
+	fd.checkDisputeGames()
+
+	oracle.AssertNumberOfCalls(t, "GetDisputeGame", 1)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.disputeGamesResolved.WithLabelValues(chain.GameStatusToString(chain.GameStatusDefenderWins), "invalid")))
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.disputeGameInvalidResolution))
+	// Only the fault of the second verification is notified again
+	slackClient.AssertNumberOfCalls(t, "PostMessageContext", 3)
+}
+
+func TestCheckDisputeGames_ChallengerWins(t *testing.T) {
+	gameAddress := common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1")
+	oracle := new(mockDisputeGameAccessor)
+	oracle.On("GetDisputeGame", big.NewInt(5)).Return(chain.DisputeGame{Address: gameAddress, Status: chain.GameStatusChallengerWins}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:                 logger,
+		oracleContractAccessor: oracle,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		currentOutputIndex:     5,
+		continuePastFaults:     true,
+	}
+
+	// The game with the invalid root claim is reported until resolved, while the later games are verified
+	fd.commitVerification(&outputVerification{outputIndex: 5, expectedOutputRoot: "0x01", calculatedOutputRoot: "0x02", finalizationTime: time.Now().Add(time.Hour)})
+	require.True(t, fd.IsFaultDetected())
+	require.Equal(t, uint64(6), fd.currentOutputIndex)
+
+	fd.checkDisputeGames()
+	require.False(t, fd.IsFaultDetected())
+	require.Empty(t, fd.DivergedOutputs())
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.stateMismatch))
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.disputeGameInvalidResolution))
+	require.Equal(t, uint64(6), fd.currentOutputIndex)
+}
+
+func TestCommitVerification_UnmonitoredDisputeGame(t *testing.T) {
+	oracle := new(mockDisputeGameAccessor)
+	oracle.On("GetL2Output", big.NewInt(5)).Return(chain.L2Output{L1Timestamp: 1700000000, L2OutputIndex: 5, Unmonitored: true}, nil)
+	verifier := new(mockOutputVerifier)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	checkpointStore := &recordingCheckpointStore{}
+	fd := &FaultDetector{
+		logger:                 logger,
+		oracleContractAccessor: oracle,
+		verifier:               verifier,
+		checkpointStore:        checkpointStore,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		currentOutputIndex:     5,
+		continuePastFaults:     true,
+	}
+
+	// The game of another type is skipped without computing its output root
+	verification, err := fd.verifyOutput(5)
+	require.NoError(t, err)
+	fd.commitVerification(verification)
+
+	verifier.AssertNotCalled(t, "computeOutputRoot", mock.Anything, mock.Anything)
+	require.Equal(t, uint64(6), fd.currentOutputIndex)
+	require.False(t, fd.IsFaultDetected())
+	require.Empty(t, fd.trackedDisputeGames)
+	require.Nil(t, fd.lastVerifiedOutput)
+	require.Len(t, checkpointStore.checkpoints, 1)
+	require.Equal(t, uint64Ptr(5), checkpointStore.checkpoints[0].LastVerifiedOutputIndex)
+}
@@ -0,0 +1,139 @@
+package faultdetector
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// addDivergedOutput records the diverged output. Unless the scan continues past the faults, it replaces the previously diverged output.
+func (fd *FaultDetector) addDivergedOutput(divergedOutput *DivergedOutput) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	if !fd.continuePastFaults {
+		for outputIndex := range fd.divergedOutputs {
+			fd.deleteDivergedOutputLocked(outputIndex)
+		}
+	}
+	if fd.divergedOutputs == nil {
+		fd.divergedOutputs = make(map[uint64]*DivergedOutput)
+	}
+	fd.divergedOutputs[divergedOutput.OutputIndex] = divergedOutput
+	fd.metrics.divergedOutput.WithLabelValues(strconv.FormatUint(divergedOutput.OutputIndex, 10)).Set(1)
+	fd.refreshDivergedLocked()
+}
+
+// removeDivergedOutputs clears the diverged outputs with index from fromOutputIndex to toOutputIndex, both inclusive, and returns them sorted by index.
+func (fd *FaultDetector) removeDivergedOutputs(fromOutputIndex uint64, toOutputIndex uint64) []*DivergedOutput {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	var removed []*DivergedOutput
+	for outputIndex, divergedOutput := range fd.divergedOutputs {
+		if outputIndex >= fromOutputIndex && outputIndex <= toOutputIndex {
+			removed = append(removed, divergedOutput)
+			fd.deleteDivergedOutputLocked(outputIndex)
+		}
+	}
+	fd.refreshDivergedLocked()
+
+	sort.Slice(removed, func(i, j int) bool { return removed[i].OutputIndex < removed[j].OutputIndex })
+	return removed
+}
+
+// deleteDivergedOutputLocked removes a single diverged output, the caller must hold the mutex.
+func (fd *FaultDetector) deleteDivergedOutputLocked(outputIndex uint64) {
+	delete(fd.divergedOutputs, outputIndex)
+	fd.metrics.divergedOutput.DeleteLabelValues(strconv.FormatUint(outputIndex, 10))
+}
+
+// refreshDiverged derives the diverged state again, as the diverged outputs leave their finalization window over time.
+func (fd *FaultDetector) refreshDiverged() {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.refreshDivergedLocked()
+}
+
+// refreshDivergedLocked derives the diverged state from the diverged outputs, the caller must hold the mutex.
+// The reported diverged output is the one with the lowest index among the outputs reported as a fault, i.e. the next to be finalized.
+func (fd *FaultDetector) refreshDivergedLocked() {
+	now := time.Now()
+	fd.divergedOutput = nil
+	for _, divergedOutput := range fd.divergedOutputs {
+		if !fd.isReportedDivergedOutput(divergedOutput, now) {
+			continue
+		}
+		if fd.divergedOutput == nil || divergedOutput.OutputIndex < fd.divergedOutput.OutputIndex {
+			fd.divergedOutput = divergedOutput
+		}
+	}
+	fd.diverged = fd.divergedOutput != nil
+
+	if fd.diverged {
+		fd.metrics.stateMismatch.Set(1)
+	} else {
+		fd.metrics.stateMismatch.Set(0)
+	}
+}
+
+// isReportedDivergedOutput returns true when the diverged output is reported as a fault at the given time.
+// When the scan continues past the faults, only the diverged outputs still within their finalization window are reported.
+func (fd *FaultDetector) isReportedDivergedOutput(divergedOutput *DivergedOutput, now time.Time) bool {
+	return !fd.continuePastFaults || divergedOutput.FinalizationTime.After(now)
+}
+
+// DivergedOutputs returns the unresolved diverged outputs, sorted by index.
+func (fd *FaultDetector) DivergedOutputs() []*DivergedOutput {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	divergedOutputs := make([]*DivergedOutput, 0, len(fd.divergedOutputs))
+	for _, divergedOutput := range fd.divergedOutputs {
+		divergedOutputs = append(divergedOutputs, divergedOutput)
+	}
+	sort.Slice(divergedOutputs, func(i, j int) bool { return divergedOutputs[i].OutputIndex < divergedOutputs[j].OutputIndex })
+	return divergedOutputs
+}
+
+// isFaultDetectedLocked returns true when an unresolved diverged output is reported, the caller must hold the mutex.
+func (fd *FaultDetector) isFaultDetectedLocked() bool {
+	now := time.Now()
+	for _, divergedOutput := range fd.divergedOutputs {
+		if fd.isReportedDivergedOutput(divergedOutput, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// recheckDivergedOutputs verifies again the diverged outputs the scan continued past, resolving the ones now matching the local view.
+// The finalized outputs are no longer verified, their output root can not be corrected.
+func (fd *FaultDetector) recheckDivergedOutputs() {
+	if !fd.continuePastFaults {
+		return
+	}
+
+	now := time.Now()
+	for _, divergedOutput := range fd.DivergedOutputs() {
+		if divergedOutput.OutputIndex >= fd.currentOutputIndex || !divergedOutput.FinalizationTime.After(now) {
+			continue
+		}
+
+		verification, err := fd.verifyOutput(divergedOutput.OutputIndex)
+		if err != nil {
+			fd.handleVerificationError(err)
+			continue
+		}
+		if !verification.isMatched() {
+			fd.recordFault(verification)
+			continue
+		}
+
+		fd.logger.Infof("Diverged output with index %d now matches the local view.", divergedOutput.OutputIndex)
+		fd.removeDivergedOutputs(divergedOutput.OutputIndex, divergedOutput.OutputIndex)
+		fd.saveCheckpoint()
+		fd.resolveFaults(divergedOutput.OutputIndex, divergedOutput.OutputIndex, FaultResolutionVerified)
+	}
+}
@@ -0,0 +1,155 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitVerification_ContinuePastFaults(t *testing.T) {
+	tests := []struct {
+		name                       string
+		continuePastFaults         bool
+		expectedCurrentOutputIndex uint64
+		expectedDivergedIndexes    []uint64
+	}{
+		{
+			name:                       "should keep checking the first diverged output by default",
+			continuePastFaults:         false,
+			expectedCurrentOutputIndex: 5,
+			expectedDivergedIndexes:    []uint64{5},
+		},
+		{
+			name:                       "should report every diverged output when the scan continues past the faults",
+			continuePastFaults:         true,
+			expectedCurrentOutputIndex: 8,
+			expectedDivergedIndexes:    []uint64{5, 7},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			fd := &FaultDetector{
+				logger:             logger,
+				metrics:            NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				mutex:              new(sync.RWMutex),
+				currentOutputIndex: 5,
+				continuePastFaults: test.continuePastFaults,
+			}
+
+			// Outputs 5 and 7 diverge, output 6 matches
+			finalizationTime := time.Now().Add(time.Hour)
+			for outputIndex := fd.currentOutputIndex; outputIndex < 8 && (test.continuePastFaults || outputIndex == 5); outputIndex++ {
+				calculatedOutputRoot := "0x01"
+				if outputIndex == 5 || outputIndex == 7 {
+					calculatedOutputRoot = "0x02"
+				}
+				fd.commitVerification(&outputVerification{outputIndex: outputIndex, expectedOutputRoot: "0x01", calculatedOutputRoot: calculatedOutputRoot, finalizationTime: finalizationTime})
+			}
+
+			require.Equal(t, test.expectedCurrentOutputIndex, fd.currentOutputIndex)
+			divergedIndexes := []uint64{}
+			for _, divergedOutput := range fd.DivergedOutputs() {
+				divergedIndexes = append(divergedIndexes, divergedOutput.OutputIndex)
+			}
+			require.Equal(t, test.expectedDivergedIndexes, divergedIndexes)
+			require.Equal(t, uint64(5), fd.divergedOutput.OutputIndex)
+			require.True(t, fd.IsFaultDetected())
+			require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.stateMismatch))
+			require.Equal(t, len(test.expectedDivergedIndexes), testutil.CollectAndCount(fd.metrics.divergedOutput))
+		})
+	}
+}
+
+func TestIsFaultDetected_ContinuePastFaults(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:             logger,
+		metrics:            NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:              new(sync.RWMutex),
+		continuePastFaults: true,
+	}
+
+	// A diverged output past its finalization window is no longer reported as a fault
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, FinalizationTime: time.Now().Add(-time.Hour)})
+	require.False(t, fd.IsFaultDetected())
+	require.Nil(t, fd.divergedOutput)
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.stateMismatch))
+
+	// The earliest diverged output within its finalization window is reported
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 7, FinalizationTime: time.Now().Add(time.Hour)})
+	require.True(t, fd.IsFaultDetected())
+	require.Equal(t, uint64(7), fd.divergedOutput.OutputIndex)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.stateMismatch))
+
+	// The reported diverged output leaves its finalization window
+	fd.divergedOutputs[7].FinalizationTime = time.Now().Add(-time.Second)
+	fd.refreshDiverged()
+	require.False(t, fd.IsFaultDetected())
+	require.Nil(t, fd.divergedOutput)
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.stateMismatch))
+
+	require.Len(t, fd.removeDivergedOutputs(6, 10), 1)
+	require.False(t, fd.IsFaultDetected())
+	require.Len(t, fd.DivergedOutputs(), 1)
+}
+
+func TestRecheckDivergedOutputs(t *testing.T) {
+	outputRoot := randHash().String()
+	oracle := new(mockOracleAccessor)
+	oracle.On("GetL2Output", big.NewInt(5)).Return(chain.L2Output{OutputRoot: outputRoot, L2BlockNumber: 600}, nil)
+	verifier := new(mockOutputVerifier)
+	verifier.On("computeOutputRoot", uint64(5), uint64(600)).Return(&providerOutput{outputRoot: outputRoot}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		oracleContractAccessor: oracle,
+		verifier:               verifier,
+		currentOutputIndex:     8,
+		continuePastFaults:     true,
+	}
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, ExpectedOutputRoot: outputRoot, FinalizationTime: time.Now().Add(time.Hour)})
+
+	// The diverged output now matches the local view, e.g. after the L2 node recovered
+	fd.recheckDivergedOutputs()
+	require.Empty(t, fd.DivergedOutputs())
+	require.False(t, fd.IsFaultDetected())
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.stateMismatch))
+}
+
+func TestRecheckDivergedOutputs_Finalized(t *testing.T) {
+	oracle := new(mockOracleAccessor)
+	verifier := new(mockOutputVerifier)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		oracleContractAccessor: oracle,
+		verifier:               verifier,
+		currentOutputIndex:     8,
+		continuePastFaults:     true,
+	}
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, FinalizationTime: time.Now().Add(-time.Hour)})
+
+	// The finalized output is kept as diverged without being verified again
+	fd.recheckDivergedOutputs()
+	oracle.AssertNotCalled(t, "GetL2Output", big.NewInt(5))
+	verifier.AssertNotCalled(t, "computeOutputRoot", uint64(5), uint64(600))
+	require.Len(t, fd.DivergedOutputs(), 1)
+}
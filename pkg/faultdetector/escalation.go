@@ -0,0 +1,71 @@
+package faultdetector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+)
+
+// finalizationEscalationCheckInterval is the longest interval between two checks of the finalization escalation, while the detector waits for the new proposals.
+const finalizationEscalationCheckInterval = time.Minute
+
+// finalizationEscalation holds the escalation progress of the currently diverged output.
+type finalizationEscalation struct {
+	outputIndex uint64
+	// level is the number of escalation thresholds already notified for the output.
+	level int
+}
+
+// sortEscalationThresholds returns the escalation thresholds sorted from the furthest to the closest to the finalization.
+func sortEscalationThresholds(thresholds []*config.EscalationThreshold) []*config.EscalationThreshold {
+	sorted := append([]*config.EscalationThreshold(nil), thresholds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before > sorted[j].Before })
+	return sorted
+}
+
+// crossedEscalationThresholds returns the number of escalation thresholds crossed when the output is finalized in untilFinalization.
+func (fd *FaultDetector) crossedEscalationThresholds(untilFinalization time.Duration) int {
+	level := 0
+	for level < len(fd.escalationThresholds) && untilFinalization <= fd.escalationThresholds[level].Before {
+		level++
+	}
+	return level
+}
+
+// checkFinalizationEscalation reports the time left until the currently diverged output is finalized and re-notifies once per crossed escalation threshold.
+// When several thresholds are crossed at once, e.g. on startup, only the closest one to the finalization is notified.
+func (fd *FaultDetector) checkFinalizationEscalation() {
+	fd.mutex.RLock()
+	divergedOutput := fd.divergedOutput
+	fd.mutex.RUnlock()
+
+	if fd.escalation != nil && (divergedOutput == nil || divergedOutput.OutputIndex != fd.escalation.outputIndex) {
+		fd.metrics.secondsUntilFinalization.DeleteLabelValues(strconv.FormatUint(fd.escalation.outputIndex, 10))
+		fd.escalation = nil
+	}
+	if divergedOutput == nil {
+		return
+	}
+	if fd.escalation == nil {
+		fd.escalation = &finalizationEscalation{outputIndex: divergedOutput.OutputIndex}
+	}
+
+	untilFinalization := time.Until(divergedOutput.FinalizationTime)
+	if untilFinalization < 0 {
+		untilFinalization = 0
+	}
+	fd.metrics.secondsUntilFinalization.WithLabelValues(strconv.FormatUint(divergedOutput.OutputIndex, 10)).Set(untilFinalization.Seconds())
+
+	level := fd.crossedEscalationThresholds(untilFinalization)
+	if level <= fd.escalation.level {
+		return
+	}
+	fd.escalation.level = level
+
+	threshold := fd.escalationThresholds[level-1]
+	fd.logger.Errorf("Diverged output with index %d is finalized in %s at %s, escalating with severity %s.", divergedOutput.OutputIndex, untilFinalization.Round(time.Second), divergedOutput.FinalizationTime, threshold.Severity)
+	fd.notifySlackChannel(threshold.SlackChannelID, fmt.Sprintf("*Fault approaching finalization*, severity %s, diverged output is finalized in %s:\nOutputIndex: %d\nExpectedStateRoot: %s\nCalculatedStateRoot: %s\nFinalizationTime: %s", threshold.Severity, untilFinalization.Round(time.Second), divergedOutput.OutputIndex, divergedOutput.ExpectedOutputRoot, divergedOutput.CalculatedOutputRoot, divergedOutput.FinalizationTime))
+}
@@ -0,0 +1,106 @@
+package faultdetector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/LiskHQ/op-fault-detector/pkg/utils/notification"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSlackClient struct {
+	mock.Mock
+}
+
+func (o *mockSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	called := o.MethodCalled("PostMessageContext", channelID)
+	return called.Get(0).(string), called.Get(1).(string), called.Error(2)
+}
+
+func TestSortEscalationThresholds(t *testing.T) {
+	thresholds := []*config.EscalationThreshold{
+		{Before: time.Hour},
+		{Before: 72 * time.Hour},
+		{Before: 6 * time.Hour},
+	}
+
+	sorted := sortEscalationThresholds(thresholds)
+	require.Equal(t, []time.Duration{72 * time.Hour, 6 * time.Hour, time.Hour}, []time.Duration{sorted[0].Before, sorted[1].Before, sorted[2].Before})
+	// The configured thresholds are left untouched
+	require.Equal(t, time.Hour, thresholds[0].Before)
+}
+
+func TestCheckFinalizationEscalation(t *testing.T) {
+	slackClient := new(mockSlackClient)
+	slackClient.On("PostMessageContext", mock.Anything).Return("", "1234569.1000", nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:   new(sync.RWMutex),
+		escalationThresholds: sortEscalationThresholds([]*config.EscalationThreshold{
+			{Before: 24 * time.Hour, Severity: "warning"},
+			{Before: 6 * time.Hour, Severity: "high"},
+			{Before: time.Hour, Severity: "critical", SlackChannelID: "OnCall"},
+		}),
+		notification: notification.GetNotification(context.Background(), logger, slackClient, &config.Notification{Slack: &config.SlackConfig{ChannelID: "Default"}}),
+	}
+
+	// Nothing is reported without a diverged output
+	fd.checkFinalizationEscalation()
+	require.Nil(t, fd.escalation)
+	slackClient.AssertNotCalled(t, "PostMessageContext", mock.Anything)
+
+	// Crossing several thresholds at once only escalates with the closest one
+	fd.divergedOutput = &DivergedOutput{OutputIndex: 5, FinalizationTime: time.Now().Add(5 * time.Hour)}
+	fd.checkFinalizationEscalation()
+	require.Equal(t, 2, fd.escalation.level)
+	require.InDelta(t, (5 * time.Hour).Seconds(), testutil.ToFloat64(fd.metrics.secondsUntilFinalization.WithLabelValues("5")), 5)
+	slackClient.AssertNumberOfCalls(t, "PostMessageContext", 1)
+	slackClient.AssertCalled(t, "PostMessageContext", "Default")
+
+	// Every threshold is only notified once
+	fd.checkFinalizationEscalation()
+	slackClient.AssertNumberOfCalls(t, "PostMessageContext", 1)
+
+	// The last threshold is routed to its own channel
+	fd.divergedOutput.FinalizationTime = time.Now().Add(30 * time.Minute)
+	fd.checkFinalizationEscalation()
+	require.Equal(t, 3, fd.escalation.level)
+	slackClient.AssertNumberOfCalls(t, "PostMessageContext", 2)
+	slackClient.AssertCalled(t, "PostMessageContext", "OnCall")
+
+	// The metric of a resolved output is removed
+	fd.divergedOutput = nil
+	fd.checkFinalizationEscalation()
+	require.Nil(t, fd.escalation)
+	require.Equal(t, 0, testutil.CollectAndCount(fd.metrics.secondsUntilFinalization))
+}
+
+func TestCheckFinalizationEscalation_ContinuePastFaults(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:               logger,
+		metrics:              NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                new(sync.RWMutex),
+		continuePastFaults:   true,
+		escalationThresholds: sortEscalationThresholds([]*config.EscalationThreshold{{Before: 24 * time.Hour, Severity: "warning"}}),
+	}
+
+	// The finalized diverged output no longer holds the escalation of the next one
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, FinalizationTime: time.Now().Add(-time.Hour)})
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 7, FinalizationTime: time.Now().Add(5 * time.Hour)})
+	fd.checkFinalizationEscalation()
+	require.Equal(t, uint64(7), fd.escalation.outputIndex)
+	require.Equal(t, 1, fd.escalation.level)
+	require.Equal(t, 1, testutil.CollectAndCount(fd.metrics.secondsUntilFinalization))
+}
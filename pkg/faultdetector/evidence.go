@@ -0,0 +1,363 @@
+package faultdetector
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrEvidenceDisabled is returned when the evidence of the diverged outputs is not archived.
+var ErrEvidenceDisabled = errors.New("evidence is not enabled")
+
+// EvidenceOutput is the output published to the oracle, as recorded in an evidence bundle.
+type EvidenceOutput struct {
+	OutputIndex   uint64      `json:"outputIndex"`
+	OutputRoot    common.Hash `json:"outputRoot"`
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	L1Timestamp   uint64      `json:"l1Timestamp"`
+}
+
+// EvidenceL1Block is the L1 block the oracle was read at, as recorded in an evidence bundle.
+type EvidenceL1Block struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// EvidenceEndpoints are the RPC endpoints the output was verified with, as recorded in an evidence bundle.
+// Only the scheme and the host of the endpoints are recorded, as the rest of the URL may hold credentials.
+type EvidenceEndpoints struct {
+	L1 string   `json:"l1"`
+	L2 []string `json:"l2"`
+}
+
+// EvidenceBundle holds everything required to verify an output offline: the output published to the oracle,
+// the header of the L2 block it commits to and the `eth_getProof` response of the L2ToL1MessagePasser account at that block.
+// The bundles archived by the fault detector also record the L1 block the oracle was read at, when the oracle reads are pinned, and the RPC endpoints used.
+// It is encoded either as JSON or as RLP.
+type EvidenceBundle struct {
+	ChainName string               `json:"chain"`
+	L2ChainID uint64               `json:"l2ChainId"`
+	Output    EvidenceOutput       `json:"output"`
+	Header    *types.Header        `json:"header"`
+	Proof     *chain.ProofResponse `json:"proof"`
+	L1Block   *EvidenceL1Block     `json:"l1Block,omitempty"`
+	Endpoints *EvidenceEndpoints   `json:"endpoints,omitempty"`
+}
+
+// rlpEvidenceBundle is the RLP encoding of [EvidenceBundle], the proof response is flattened as it has no RLP encoding of its own.
+type rlpEvidenceBundle struct {
+	ChainName     string
+	L2ChainID     uint64
+	OutputIndex   uint64
+	OutputRoot    common.Hash
+	L2BlockNumber uint64
+	L1Timestamp   uint64
+	Header        *types.Header
+	Address       common.Address
+	AccountProof  [][]byte
+	Balance       *big.Int
+	CodeHash      common.Hash
+	Nonce         uint64
+	StorageHash   common.Hash
+	StorageProof  []common.Hash
+	L1BlockNumber uint64      `rlp:"optional"`
+	L1BlockHash   common.Hash `rlp:"optional"`
+	L1Endpoint    string      `rlp:"optional"`
+	L2Endpoints   []string    `rlp:"optional"`
+}
+
+// EncodeRLP implements [rlp.Encoder].
+func (b *EvidenceBundle) EncodeRLP(w io.Writer) error {
+	if b.Header == nil || b.Proof == nil {
+		return errors.New("evidence bundle without L2 block header or proof cannot be encoded")
+	}
+
+	accountProof := make([][]byte, len(b.Proof.AccountProof))
+	for i, node := range b.Proof.AccountProof {
+		accountProof[i] = node
+	}
+	balance := new(big.Int)
+	if b.Proof.Balance != nil {
+		balance = b.Proof.Balance.ToInt()
+	}
+
+	encoded := &rlpEvidenceBundle{
+		ChainName:     b.ChainName,
+		L2ChainID:     b.L2ChainID,
+		OutputIndex:   b.Output.OutputIndex,
+		OutputRoot:    b.Output.OutputRoot,
+		L2BlockNumber: b.Output.L2BlockNumber,
+		L1Timestamp:   b.Output.L1Timestamp,
+		Header:        b.Header,
+		Address:       b.Proof.Address,
+		AccountProof:  accountProof,
+		Balance:       balance,
+		CodeHash:      b.Proof.CodeHash,
+		Nonce:         uint64(b.Proof.Nonce),
+		StorageHash:   b.Proof.StorageHash,
+		StorageProof:  b.Proof.StorageProof,
+	}
+	if b.L1Block != nil {
+		encoded.L1BlockNumber = b.L1Block.Number
+		encoded.L1BlockHash = b.L1Block.Hash
+	}
+	if b.Endpoints != nil {
+		encoded.L1Endpoint = b.Endpoints.L1
+		encoded.L2Endpoints = b.Endpoints.L2
+	}
+
+	return rlp.Encode(w, encoded)
+}
+
+// DecodeRLP implements [rlp.Decoder].
+func (b *EvidenceBundle) DecodeRLP(s *rlp.Stream) error {
+	var decoded rlpEvidenceBundle
+	if err := s.Decode(&decoded); err != nil {
+		return err
+	}
+
+	accountProof := make([]hexutil.Bytes, len(decoded.AccountProof))
+	for i, node := range decoded.AccountProof {
+		accountProof[i] = node
+	}
+
+	*b = EvidenceBundle{
+		ChainName: decoded.ChainName,
+		L2ChainID: decoded.L2ChainID,
+		Output: EvidenceOutput{
+			OutputIndex:   decoded.OutputIndex,
+			OutputRoot:    decoded.OutputRoot,
+			L2BlockNumber: decoded.L2BlockNumber,
+			L1Timestamp:   decoded.L1Timestamp,
+		},
+		Header: decoded.Header,
+		Proof: &chain.ProofResponse{
+			Address:      decoded.Address,
+			AccountProof: accountProof,
+			Balance:      (*hexutil.Big)(decoded.Balance),
+			CodeHash:     decoded.CodeHash,
+			Nonce:        hexutil.Uint64(decoded.Nonce),
+			StorageHash:  decoded.StorageHash,
+			StorageProof: decoded.StorageProof,
+		},
+	}
+	if decoded.L1BlockHash != (common.Hash{}) {
+		b.L1Block = &EvidenceL1Block{Number: decoded.L1BlockNumber, Hash: decoded.L1BlockHash}
+	}
+	if len(decoded.L1Endpoint) > 0 || len(decoded.L2Endpoints) > 0 {
+		b.Endpoints = &EvidenceEndpoints{L1: decoded.L1Endpoint, L2: decoded.L2Endpoints}
+	}
+	return nil
+}
+
+// DecodeEvidenceBundle decodes an evidence bundle encoded either as JSON or as RLP.
+func DecodeEvidenceBundle(content []byte) (*EvidenceBundle, error) {
+	bundle := &EvidenceBundle{}
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, bundle); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON evidence bundle: %w", err)
+		}
+		return bundle, nil
+	}
+
+	if err := rlp.DecodeBytes(content, bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode RLP evidence bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+// EvidenceVerification is the result of verifying an evidence bundle offline, along with the intermediate values the output root is computed from.
+type EvidenceVerification struct {
+	OutputIndex              uint64 `json:"outputIndex"`
+	L2BlockNumber            uint64 `json:"l2BlockNumber"`
+	L1Timestamp              uint64 `json:"l1Timestamp"`
+	BlockHash                string `json:"blockHash"`
+	StateRoot                string `json:"stateRoot"`
+	MessagePasserStorageRoot string `json:"messagePasserStorageRoot"`
+	ProofValid               bool   `json:"proofValid"`
+	ExpectedOutputRoot       string `json:"expectedOutputRoot"`
+	CalculatedOutputRoot     string `json:"calculatedOutputRoot"`
+	Verdict                  string `json:"verdict"`
+	Error                    string `json:"error,omitempty"`
+}
+
+// VerifyEvidence recomputes the output root from the L2 block header and the message passer account proof of the bundle and compares it with the output root published to the oracle.
+// The verdict is `error` when the header is not the block of the output or the account proof does not match its state root, as the computed output root cannot be trusted.
+func VerifyEvidence(bundle *EvidenceBundle) *EvidenceVerification {
+	verification := &EvidenceVerification{
+		OutputIndex:        bundle.Output.OutputIndex,
+		L2BlockNumber:      bundle.Output.L2BlockNumber,
+		L1Timestamp:        bundle.Output.L1Timestamp,
+		ExpectedOutputRoot: bundle.Output.OutputRoot.Hex(),
+		Verdict:            VerdictError,
+	}
+	if bundle.Header == nil || bundle.Proof == nil {
+		verification.Error = "evidence bundle without L2 block header or proof"
+		return verification
+	}
+
+	blockHash := bundle.Header.Hash()
+	verification.BlockHash = blockHash.Hex()
+	verification.StateRoot = bundle.Header.Root.Hex()
+	verification.MessagePasserStorageRoot = bundle.Proof.StorageHash.Hex()
+	verification.CalculatedOutputRoot = encoding.ComputeL2OutputRoot(bundle.Header.Root, bundle.Proof.StorageHash, blockHash)
+
+	if bundle.Header.Number == nil || bundle.Header.Number.Uint64() != bundle.Output.L2BlockNumber {
+		verification.Error = fmt.Sprintf("%v: header of block %v given for the output of block %d", errHeaderIntegrity, bundle.Header.Number, bundle.Output.L2BlockNumber)
+		return verification
+	}
+
+	if err := verifyAccountProof(bundle.Header.Root, common.HexToAddress(chain.L2BedrockMessagePasserAddress), bundle.Proof); err != nil {
+		verification.Error = err.Error()
+		return verification
+	}
+	verification.ProofValid = true
+
+	verification.Verdict = VerdictOk
+	if verification.CalculatedOutputRoot != verification.ExpectedOutputRoot {
+		verification.Verdict = VerdictMismatch
+	}
+	return verification
+}
+
+// EvidenceStore archives the evidence bundles of the diverged outputs.
+type EvidenceStore interface {
+	// Save stores the evidence bundle, unless the evidence of the same output proposal is already stored.
+	Save(bundle *EvidenceBundle) error
+	// Load returns the evidence bundles of every proposal of the output with the given index, in the order of L1 timestamp.
+	Load(outputIndex uint64) ([]*EvidenceBundle, error)
+}
+
+// FileEvidenceStore is an [EvidenceStore] that writes every evidence bundle as a JSON file on the local disk.
+type FileEvidenceStore struct {
+	directory string
+	chainID   uint64
+}
+
+// NewFileEvidenceStore returns [FileEvidenceStore] storing the evidence bundles for the given chainID in the given directory.
+func NewFileEvidenceStore(directory string, chainID uint64) (*FileEvidenceStore, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create evidence directory %s: %w", directory, err)
+	}
+
+	return &FileEvidenceStore{
+		directory: directory,
+		chainID:   chainID,
+	}, nil
+}
+
+// Save writes the evidence bundle to the file `evidence_{L2_CHAIN_ID}_{OUTPUT_INDEX}_{L1_TIMESTAMP}.json`, an output proposed again after a deletion is stored in a new file.
+// The first evidence of an output proposal is kept, even though a diverged output is verified again until it is resolved.
+func (s *FileEvidenceStore) Save(bundle *EvidenceBundle) error {
+	filePath := filepath.Join(s.directory, fmt.Sprintf("evidence_%d_%d_%d.json", s.chainID, bundle.Output.OutputIndex, bundle.Output.L1Timestamp))
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, content, checkpointFilePermission); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// Load reads the evidence bundles of the output with the given index from the files, returns nil when none is stored.
+func (s *FileEvidenceStore) Load(outputIndex uint64) ([]*EvidenceBundle, error) {
+	filePaths, err := filepath.Glob(filepath.Join(s.directory, fmt.Sprintf("evidence_%d_%d_*.json", s.chainID, outputIndex)))
+	if err != nil {
+		return nil, err
+	}
+
+	var bundles []*EvidenceBundle
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		bundle, err := DecodeEvidenceBundle(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode evidence file %s: %w", filePath, err)
+		}
+		bundles = append(bundles, bundle)
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].Output.L1Timestamp < bundles[j].Output.L1Timestamp
+	})
+
+	return bundles, nil
+}
+
+// redactEndpoint returns the scheme and the host of the RPC endpoint, dropping the path, the query and the user info that may hold credentials.
+func redactEndpoint(endpoint string) string {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || len(endpointURL.Host) == 0 {
+		return "<redacted>"
+	}
+	return fmt.Sprintf("%s://%s", endpointURL.Scheme, endpointURL.Host)
+}
+
+// saveEvidence archives the evidence bundle of the diverged output, when enabled.
+// The evidence is only available when the output root is computed from the block header and the account proof, i.e. not with the `rollup_node` verifier.
+func (fd *FaultDetector) saveEvidence(verification *outputVerification) {
+	if fd.evidenceStore == nil || verification.header == nil || verification.proof == nil {
+		return
+	}
+
+	bundle := &EvidenceBundle{
+		ChainName: fd.chainName,
+		L2ChainID: fd.l2ChainID,
+		Output: EvidenceOutput{
+			OutputIndex:   verification.outputIndex,
+			OutputRoot:    common.HexToHash(verification.expectedOutputRoot),
+			L2BlockNumber: verification.l2BlockNumber,
+			L1Timestamp:   verification.l1Timestamp,
+		},
+		Header: verification.header,
+		Proof:  verification.proof,
+		Endpoints: &EvidenceEndpoints{
+			L1: redactEndpoint(fd.l1RPCEndpoint),
+			L2: make([]string, 0, len(verification.endpoints)),
+		},
+	}
+	for _, endpoint := range verification.endpoints {
+		bundle.Endpoints.L2 = append(bundle.Endpoints.L2, redactEndpoint(endpoint))
+	}
+	if verification.l1Block != nil {
+		bundle.L1Block = &EvidenceL1Block{Number: verification.l1Block.Number.Uint64(), Hash: verification.l1Block.Hash()}
+	}
+
+	if err := fd.evidenceStore.Save(bundle); err != nil {
+		fd.logger.Errorf("Failed to save evidence of diverged output index %d, error: %v", verification.outputIndex, err)
+	}
+}
+
+// Evidence returns the archived evidence bundles of every proposal of the output with the given index, in the order of L1 timestamp.
+// It returns [ErrEvidenceDisabled] when the evidence is not archived.
+func (fd *FaultDetector) Evidence(outputIndex uint64) ([]*EvidenceBundle, error) {
+	if fd.evidenceStore == nil {
+		return nil, ErrEvidenceDisabled
+	}
+
+	return fd.evidenceStore.Load(outputIndex)
+}
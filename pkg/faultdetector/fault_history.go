@@ -0,0 +1,242 @@
+package faultdetector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Resolutions of the faults in the fault history.
+const (
+	FaultResolutionUnresolved = "unresolved"
+	FaultResolutionVerified   = "verified"
+	FaultResolutionDeleted    = "deleted"
+	FaultResolutionReorged    = "reorged"
+	FaultResolutionChallenged = "challenged"
+)
+
+// FaultRecord is the entry of the fault history for a single detected fault.
+type FaultRecord struct {
+	ChainName            string     `json:"chain"`
+	OutputIndex          uint64     `json:"outputIndex"`
+	L2BlockNumber        uint64     `json:"l2BlockNumber"`
+	L1Timestamp          uint64     `json:"l1Timestamp"`
+	ExpectedOutputRoot   string     `json:"expectedOutputRoot"`
+	CalculatedOutputRoot string     `json:"calculatedOutputRoot"`
+	FinalizationTime     time.Time  `json:"finalizationTime"`
+	FirstSeenAt          time.Time  `json:"firstSeenAt"`
+	LastSeenAt           time.Time  `json:"lastSeenAt"`
+	Proposer             string     `json:"proposer,omitempty"`
+	L1TxHash             string     `json:"l1TxHash,omitempty"`
+	Resolution           string     `json:"resolution"`
+	ResolvedAt           *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// FaultHistoryStore persists and restores the fault history.
+type FaultHistoryStore interface {
+	// Load returns the saved fault records or nil when no fault history has been saved yet.
+	Load() ([]*FaultRecord, error)
+	Save(records []*FaultRecord) error
+}
+
+// FileFaultHistoryStore is a [FaultHistoryStore] that keeps the fault history as a JSON file on the local disk.
+type FileFaultHistoryStore struct {
+	filePath string
+}
+
+// NewFileFaultHistoryStore returns [FileFaultHistoryStore] storing the fault history for the given chainID in the given directory.
+func NewFileFaultHistoryStore(directory string, chainID uint64) (*FileFaultHistoryStore, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create fault history directory %s: %w", directory, err)
+	}
+
+	return &FileFaultHistoryStore{
+		filePath: filepath.Join(directory, fmt.Sprintf("fault_history_%d.json", chainID)),
+	}, nil
+}
+
+// Load reads the fault history from the file, returns nil when the file does not exist.
+func (s *FileFaultHistoryStore) Load() ([]*FaultRecord, error) {
+	content, err := os.ReadFile(s.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*FaultRecord
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode fault history file %s: %w", s.filePath, err)
+	}
+
+	return records, nil
+}
+
+// Save writes the fault history to a temporary file and renames it, so that a crash never leaves a partially written fault history.
+func (s *FileFaultHistoryStore) Save(records []*FaultRecord) error {
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, content, checkpointFilePermission); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilePath, s.filePath)
+}
+
+// faultHistory keeps a record of every fault detected on a chain, persisted to the store when enabled.
+type faultHistory struct {
+	mutex   sync.RWMutex
+	records []*FaultRecord
+	store   FaultHistoryStore
+}
+
+// newFaultHistory returns the fault history restored from the given store, or an empty in-memory fault history when the store is nil.
+func newFaultHistory(store FaultHistoryStore) (*faultHistory, error) {
+	history := &faultHistory{store: store}
+	if store == nil {
+		return history, nil
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	history.records = records
+
+	return history, nil
+}
+
+// recordFault adds the diverged output to the fault history, or updates its last seen time when the fault is already recorded and unresolved.
+func (h *faultHistory) recordFault(chainName string, verification *outputVerification, seenAt time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, record := range h.records {
+		if record.Resolution == FaultResolutionUnresolved && record.OutputIndex == verification.outputIndex && record.ExpectedOutputRoot == verification.expectedOutputRoot {
+			record.CalculatedOutputRoot = verification.calculatedOutputRoot
+			record.FinalizationTime = verification.finalizationTime
+			record.LastSeenAt = seenAt
+			return h.save()
+		}
+	}
+
+	record := &FaultRecord{
+		ChainName:            chainName,
+		OutputIndex:          verification.outputIndex,
+		L2BlockNumber:        verification.l2BlockNumber,
+		L1Timestamp:          verification.l1Timestamp,
+		ExpectedOutputRoot:   verification.expectedOutputRoot,
+		CalculatedOutputRoot: verification.calculatedOutputRoot,
+		FinalizationTime:     verification.finalizationTime,
+		FirstSeenAt:          seenAt,
+		LastSeenAt:           seenAt,
+		Resolution:           FaultResolutionUnresolved,
+	}
+	if verification.proposal != nil {
+		record.Proposer = verification.proposal.Sender.Hex()
+		record.L1TxHash = verification.proposal.L1TxHash.Hex()
+	}
+	h.records = append(h.records, record)
+	return h.save()
+}
+
+// resolveFaults marks the unresolved faults of the outputs with index from fromOutputIndex to toOutputIndex, both inclusive, with the given resolution.
+func (h *faultHistory) resolveFaults(fromOutputIndex uint64, toOutputIndex uint64, resolution string, resolvedAt time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	resolved := false
+	for _, record := range h.records {
+		if record.Resolution == FaultResolutionUnresolved && record.OutputIndex >= fromOutputIndex && record.OutputIndex <= toOutputIndex {
+			record.Resolution = resolution
+			record.ResolvedAt = &resolvedAt
+			resolved = true
+		}
+	}
+	if !resolved {
+		return nil
+	}
+
+	return h.save()
+}
+
+// refreshFinalizationTimes recomputes the finalization time of the unresolved faults with the new finalization period.
+func (h *faultHistory) refreshFinalizationTimes(previousPeriodSeconds uint64, periodSeconds uint64) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	refreshed := false
+	for _, record := range h.records {
+		if record.Resolution == FaultResolutionUnresolved {
+			record.FinalizationTime = refreshFinalizationTime(record.L1Timestamp, record.FinalizationTime, previousPeriodSeconds, periodSeconds)
+			refreshed = true
+		}
+	}
+	if !refreshed {
+		return nil
+	}
+
+	return h.save()
+}
+
+// list returns a copy of all the fault records in the order they were first detected.
+func (h *faultHistory) list() []*FaultRecord {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	records := make([]*FaultRecord, len(h.records))
+	for i, record := range h.records {
+		copied := *record
+		records[i] = &copied
+	}
+	return records
+}
+
+// save persists the fault history, if the store is enabled. It must be invoked with the lock held.
+func (h *faultHistory) save() error {
+	if h.store == nil {
+		return nil
+	}
+
+	return h.store.Save(h.records)
+}
+
+// recordFault adds the diverged output to the fault history.
+func (fd *FaultDetector) recordFault(verification *outputVerification) {
+	if fd.faultHistory == nil {
+		return
+	}
+
+	if err := fd.faultHistory.recordFault(fd.chainName, verification, time.Now()); err != nil {
+		fd.logger.Errorf("Failed to save fault history with diverged output index %d, error: %v", verification.outputIndex, err)
+	}
+}
+
+// resolveFaults resolves the recorded faults of the outputs with index from fromOutputIndex to toOutputIndex, both inclusive.
+func (fd *FaultDetector) resolveFaults(fromOutputIndex uint64, toOutputIndex uint64, resolution string) {
+	if fd.faultHistory == nil {
+		return
+	}
+
+	if err := fd.faultHistory.resolveFaults(fromOutputIndex, toOutputIndex, resolution, time.Now()); err != nil {
+		fd.logger.Errorf("Failed to save fault history with faults resolved as %s, error: %v", resolution, err)
+	}
+}
+
+// FaultHistory returns all the faults detected on the monitored chain, in the order they were first detected.
+func (fd *FaultDetector) FaultHistory() []*FaultRecord {
+	if fd.faultHistory == nil {
+		return nil
+	}
+
+	return fd.faultHistory.list()
+}
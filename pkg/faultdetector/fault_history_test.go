@@ -0,0 +1,81 @@
+package faultdetector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultHistory(t *testing.T) {
+	const chainID uint64 = 4202
+	verification := &outputVerification{
+		outputIndex:          11,
+		l2BlockNumber:        1800,
+		l1Timestamp:          1500,
+		expectedOutputRoot:   randHash().String(),
+		calculatedOutputRoot: randHash().String(),
+		finalizationTime:     time.Unix(2000, 0).UTC(),
+	}
+
+	t.Run("should record a fault once and update its last seen time", func(t *testing.T) {
+		history, err := newFaultHistory(nil)
+		require.NoError(t, err)
+
+		require.NoError(t, history.recordFault("mainnet", verification, time.Unix(100, 0).UTC()))
+		require.NoError(t, history.recordFault("mainnet", verification, time.Unix(200, 0).UTC()))
+
+		records := history.list()
+		require.Len(t, records, 1)
+		require.Equal(t, &FaultRecord{
+			ChainName:            "mainnet",
+			OutputIndex:          11,
+			L2BlockNumber:        1800,
+			L1Timestamp:          1500,
+			ExpectedOutputRoot:   verification.expectedOutputRoot,
+			CalculatedOutputRoot: verification.calculatedOutputRoot,
+			FinalizationTime:     verification.finalizationTime,
+			FirstSeenAt:          time.Unix(100, 0).UTC(),
+			LastSeenAt:           time.Unix(200, 0).UTC(),
+			Resolution:           FaultResolutionUnresolved,
+		}, records[0])
+	})
+
+	t.Run("should record a new fault after the previous one is resolved", func(t *testing.T) {
+		history, err := newFaultHistory(nil)
+		require.NoError(t, err)
+
+		require.NoError(t, history.recordFault("mainnet", verification, time.Unix(100, 0).UTC()))
+		require.NoError(t, history.resolveFaults(10, 10, FaultResolutionVerified, time.Unix(150, 0).UTC()))
+		require.Equal(t, FaultResolutionUnresolved, history.list()[0].Resolution)
+
+		require.NoError(t, history.resolveFaults(11, 20, FaultResolutionDeleted, time.Unix(150, 0).UTC()))
+		require.NoError(t, history.recordFault("mainnet", verification, time.Unix(200, 0).UTC()))
+
+		records := history.list()
+		require.Len(t, records, 2)
+		require.Equal(t, FaultResolutionDeleted, records[0].Resolution)
+		require.Equal(t, time.Unix(150, 0).UTC(), *records[0].ResolvedAt)
+		require.Equal(t, FaultResolutionUnresolved, records[1].Resolution)
+		require.Nil(t, records[1].ResolvedAt)
+	})
+
+	t.Run("should restore the fault history from the store", func(t *testing.T) {
+		directory := t.TempDir()
+		store, err := NewFileFaultHistoryStore(directory, chainID)
+		require.NoError(t, err)
+
+		records, err := store.Load()
+		require.NoError(t, err)
+		require.Nil(t, records)
+
+		history, err := newFaultHistory(store)
+		require.NoError(t, err)
+		require.NoError(t, history.recordFault("mainnet", verification, time.Unix(100, 0).UTC()))
+		require.NoError(t, history.resolveFaults(11, 11, FaultResolutionReorged, time.Unix(150, 0).UTC()))
+
+		restored, err := newFaultHistory(store)
+		require.NoError(t, err)
+		require.Equal(t, history.list(), restored.list())
+	})
+}
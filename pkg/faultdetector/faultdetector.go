@@ -3,7 +3,9 @@ package faultdetector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -13,42 +15,116 @@ import (
 	"github.com/LiskHQ/op-fault-detector/pkg/log"
 	"github.com/LiskHQ/op-fault-detector/pkg/utils/notification"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
-	serviceIntervalInSeconds = 1
-	waitTimeInFailure        = 10
+	resubscribeIntervalInSeconds = 60
 )
 
 // FaultDetector contains all the RPC providers/contract accessors and holds state information.
 type FaultDetector struct {
-	ctx                    context.Context
-	logger                 log.Logger
-	errorChan              chan error
-	wg                     *sync.WaitGroup
-	metrics                *faultDetectorMetrics
-	l1RpcApi               *chain.ChainAPIClient
-	l2RpcApi               *chain.ChainAPIClient
-	oracleContractAccessor OracleAccessor
-	faultProofWindow       uint64
-	currentOutputIndex     uint64
-	diverged               bool
-	ticker                 *time.Ticker
-	quitTickerChan         chan struct{}
-	notification           *notification.Notification
-	mutex                  *sync.RWMutex
+	ctx                        context.Context
+	logger                     log.Logger
+	errorChan                  chan error
+	wg                         *sync.WaitGroup
+	metrics                    *faultDetectorMetrics
+	chainName                  string
+	l1RpcApi                   *chain.ChainAPIClient
+	l1RPCEndpoint              string
+	l2RpcApi                   *chain.ChainAPIClient
+	verifier                   outputVerifier
+	lastNodeInconsistency      *nodeInconsistency
+	pinnedL1Block              *types.Header
+	lastReorgCheckL1Block      common.Hash
+	lastNextOutputIndex        uint64
+	lastNextOutputL1Block      uint64
+	lastVerifiedOutput         *verifiedOutput
+	submissionInterval         time.Duration
+	livenessIntervalMultiplier float64
+	lastProposalIndex          uint64
+	lastProposalTime           time.Time
+	proposerStalled            bool
+	outputProposalReader       OutputProposalReader
+	proposerAllowlist          map[common.Address]bool
+	lastUnexpectedProposal     *chain.OutputProposal
+	oracleParametersReader     OracleParametersReader
+	oracleParametersInterval   time.Duration
+	oracleParameters           *chain.OracleParameters
+	lastOracleParametersCheck  time.Time
+	outputInvariants           []outputInvariant
+	reportedViolations         map[reportedInvariantViolation]bool
+	escalationThresholds       []*config.EscalationThreshold
+	escalation                 *finalizationEscalation
+	oracleContractAccessor     OracleAccessor
+	faultProofWindow           uint64
+	currentOutputIndex         uint64
+	diverged                   bool
+	divergedOutput             *DivergedOutput
+	divergedOutputs            map[uint64]*DivergedOutput
+	continuePastFaults         bool
+	state                      State
+	stateSince                 time.Time
+	l2ChainID                  uint64
+	checkpointStore            CheckpointStore
+	faultHistory               *faultHistory
+	evidenceStore              EvidenceStore
+	lastVerifiedIndex          *uint64
+	catchUpThreshold           uint64
+	catchUpWindowSize          uint64
+	catchUpWorkers             uint
+	trackedDisputeGames        map[uint64]*trackedDisputeGame
+	resolvedDisputeGames       map[uint64]bool
+	lastDisputeGamesCheck      time.Time
+	scheduler                  *scheduler
+	timer                      *time.Timer
+	quitTickerChan             chan struct{}
+	outputSubscriber           OutputSubscriber
+	outputSubscription         event.Subscription
+	proposedOutputs            chan chain.L2Output
+	awaitedOutput              *chain.L2Output
+	deletedOutputs             chan chain.OutputsDeleted
+	lastSubscribeAttempt       time.Time
+	notification               *notification.Notification
+	mutex                      *sync.RWMutex
 }
 
 type faultDetectorMetrics struct {
-	highestOutputIndex   prometheus.Gauge
-	stateMismatch        prometheus.Gauge
-	apiConnectionFailure prometheus.Gauge
+	highestOutputIndex           prometheus.Gauge
+	stateMismatch                prometheus.Gauge
+	apiConnectionFailure         prometheus.Gauge
+	disputeGameStatus            *prometheus.GaugeVec
+	disputeGamesResolved         *prometheus.GaugeVec
+	disputeGameInvalidResolution prometheus.Gauge
+	nodeInconsistency            prometheus.Gauge
+	reorgedOutputs               prometheus.Gauge
+	deletedOutputs               prometheus.Gauge
+	state                        *prometheus.GaugeVec
+	proposerStalled              prometheus.Gauge
+	unexpectedProposer           prometheus.Gauge
+	oracleParameterChanges       *prometheus.GaugeVec
+	secondsUntilFinalization     *prometheus.GaugeVec
+	rpcIntegrityFailure          prometheus.Gauge
+	oracleInvariantViolations    *prometheus.GaugeVec
+	divergedOutput               *prometheus.GaugeVec
 }
 
 // NewFaultDetectorMetrics returns [FaultDetectorMetrics] with initialized metrics and registering to prometheus registry.
+// It panics when the metrics are already registered.
 func NewFaultDetectorMetrics(reg prometheus.Registerer) *faultDetectorMetrics {
-	m := &faultDetectorMetrics{
+	m := newFaultDetectorMetrics()
+	for _, collector := range m.collectors() {
+		reg.MustRegister(collector)
+	}
+
+	return m
+}
+
+// newFaultDetectorMetrics returns [FaultDetectorMetrics] with initialized metrics, not registered to any prometheus registry.
+func newFaultDetectorMetrics() *faultDetectorMetrics {
+	return &faultDetectorMetrics{
 		highestOutputIndex: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "fault_detector_highest_output_index",
@@ -62,69 +138,183 @@ func NewFaultDetectorMetrics(reg prometheus.Registerer) *faultDetectorMetrics {
 			Name: "fault_detector_api_connection_failure",
 			Help: "Number of times API call failed",
 		}),
+		disputeGameStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_dispute_game_status",
+			Help: "Status of the verified dispute games that are in progress, 0 when in progress, 1 when challenger wins, 2 when defender wins",
+		}, []string{"game_index", "game_address"}),
+		disputeGamesResolved: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_dispute_games_resolved",
+			Help: "Number of verified dispute games resolved, by resolution status and validity of the root claim",
+		}, []string{"status", "root_claim"}),
+		disputeGameInvalidResolution: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_dispute_game_invalid_resolution",
+			Help: "Number of dispute games resolved in favor of an invalid root claim or against a valid root claim",
+		}),
+		nodeInconsistency: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_node_inconsistency",
+			Help: "Number of outputs for which the L2 providers computed different output roots",
+		}),
+		reorgedOutputs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_reorged_outputs",
+			Help: "Number of output proposals that disappeared from the oracle after an L1 reorg",
+		}),
+		deletedOutputs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_deleted_outputs",
+			Help: "Number of outputs deleted from the oracle",
+		}),
+		state: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_state",
+			Help: "State of the fault detector, 1 for the current state and 0 for the others",
+		}, []string{"state"}),
+		proposerStalled: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_proposer_stalled",
+			Help: "0 when the outputs are proposed within the expected interval, 1 when overdue",
+		}),
+		unexpectedProposer: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_unexpected_proposer",
+			Help: "Number of outputs proposed by an address other than the oracle proposer or the configured allowlist",
+		}),
+		oracleParameterChanges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_oracle_parameter_changes",
+			Help: "Number of changes of the oracle proxy implementation and parameters detected since startup",
+		}, []string{"parameter"}),
+		secondsUntilFinalization: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_seconds_until_finalization",
+			Help: "Seconds until the currently diverged output is finalized, 0 once finalized",
+		}, []string{"output_index"}),
+		rpcIntegrityFailure: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fault_detector_rpc_integrity_failure",
+			Help: "Number of inconsistent Merkle proofs and block headers served by the L2 providers",
+		}),
+		oracleInvariantViolations: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_oracle_invariant_violations",
+			Help: "Number of outputs violating the structural invariants of the oracle",
+		}, []string{"invariant"}),
+		divergedOutput: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fault_detector_diverged_output",
+			Help: "1 for every unresolved diverged output",
+		}, []string{"output_index"}),
 	}
-	reg.MustRegister(m.highestOutputIndex)
-	reg.MustRegister(m.stateMismatch)
-	reg.MustRegister(m.apiConnectionFailure)
-
-	return m
 }
 
-// NewFaultDetector will return [FaultDetector] with the initialized providers and configuration.
-func NewFaultDetector(ctx context.Context, logger log.Logger, errorChan chan error, wg *sync.WaitGroup, faultDetectorConfig *config.FaultDetectorConfig, metricRegistry *prometheus.Registry, notification *notification.Notification) (*FaultDetector, error) {
-	// Initialize API Providers
-	l1RpcApi, err := chain.GetAPIClient(ctx, faultDetectorConfig.L1RPCEndpoint, logger)
-	if err != nil {
-		logger.Errorf("Failed to create API client for L1 Provider with given endpoint: %s, error: %v", faultDetectorConfig.L1RPCEndpoint, err)
-		return nil, err
+// collectors returns every metric of the fault detector.
+func (m *faultDetectorMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.highestOutputIndex,
+		m.stateMismatch,
+		m.apiConnectionFailure,
+		m.disputeGameStatus,
+		m.disputeGamesResolved,
+		m.disputeGameInvalidResolution,
+		m.nodeInconsistency,
+		m.reorgedOutputs,
+		m.deletedOutputs,
+		m.state,
+		m.proposerStalled,
+		m.unexpectedProposer,
+		m.oracleParameterChanges,
+		m.secondsUntilFinalization,
+		m.rpcIntegrityFailure,
+		m.oracleInvariantViolations,
+		m.divergedOutput,
 	}
+}
 
-	l2RpcApi, err := chain.GetAPIClient(ctx, faultDetectorConfig.L2RPCEndpoint, logger)
-	if err != nil {
-		logger.Errorf("Failed to create API client for L2 Provider with given endpoint: %s, error: %v", faultDetectorConfig.L2RPCEndpoint, err)
-		return nil, err
+// register registers every metric of the fault detector to the prometheus registry, it returns an error when any metric is already registered.
+func (m *faultDetectorMetrics) register(reg prometheus.Registerer) error {
+	for _, collector := range m.collectors() {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	l2ChainID, err := l2RpcApi.GetChainID(ctx)
+// NewFaultDetector will return [FaultDetector] with the initialized providers and configuration.
+// Every fault detector monitors a single chain, its metrics are labelled with the chain name.
+func NewFaultDetector(ctx context.Context, logger log.Logger, errorChan chan error, wg *sync.WaitGroup, faultDetectorConfig *config.FaultDetectorConfig, metricRegistry prometheus.Registerer, notification *notification.Notification) (*FaultDetector, error) {
+	faultDetector, err := newFaultDetector(ctx, logger, faultDetectorConfig, metricRegistry)
 	if err != nil {
-		logger.Errorf("Failed to get L2 provider's chainID: %d, error: %v", encoding.MustConvertBigIntToUint64(l2ChainID), err)
 		return nil, err
 	}
+	logger = faultDetector.logger
 
-	// Initialize Oracle contract accessor
-	chainConfig := &chain.ConfigOptions{
-		L1RPCEndpoint:                 faultDetectorConfig.L1RPCEndpoint,
-		ChainID:                       encoding.MustConvertBigIntToUint64(l2ChainID),
-		L2OutputOracleContractAddress: faultDetectorConfig.L2OutputOracleContractAddress,
+	// Initialize checkpoint store and load the last saved progress, if enabled
+	var checkpointStore CheckpointStore
+	var checkpoint *Checkpoint
+	if faultDetectorConfig.Checkpoint != nil && faultDetectorConfig.Checkpoint.Enable {
+		checkpointStore, err = NewFileCheckpointStore(faultDetectorConfig.Checkpoint.Directory, faultDetector.l2ChainID)
+		if err != nil {
+			logger.Errorf("Failed to create checkpoint store in directory: %s, error: %v", faultDetectorConfig.Checkpoint.Directory, err)
+			return nil, err
+		}
+
+		checkpoint, err = checkpointStore.Load()
+		if err != nil {
+			logger.Errorf("Failed to load checkpoint, error: %v", err)
+			return nil, err
+		}
 	}
 
-	oracleContractAccessor, err := chain.NewOracleAccessor(ctx, chainConfig)
+	// Initialize fault history store and load the recorded faults, if enabled
+	var faultHistoryStore FaultHistoryStore
+	if faultDetectorConfig.FaultHistory != nil && faultDetectorConfig.FaultHistory.Enable {
+		faultHistoryStore, err = NewFileFaultHistoryStore(faultDetectorConfig.FaultHistory.Directory, faultDetector.l2ChainID)
+		if err != nil {
+			logger.Errorf("Failed to create fault history store in directory: %s, error: %v", faultDetectorConfig.FaultHistory.Directory, err)
+			return nil, err
+		}
+	}
+	faultHistory, err := newFaultHistory(faultHistoryStore)
 	if err != nil {
-		logger.Errorf("Failed to create Oracle contract accessor with chainID: %d, L1 endpoint: %s and L2OutputOracleContractAddress: %s, error: %v", encoding.MustConvertBigIntToUint64(l2ChainID), faultDetectorConfig.L1RPCEndpoint, faultDetectorConfig.L2OutputOracleContractAddress, err)
+		logger.Errorf("Failed to load fault history, error: %v", err)
 		return nil, err
 	}
 
-	finalizedPeriodSeconds, err := oracleContractAccessor.FinalizationPeriodSeconds()
-	if err != nil {
-		logger.Errorf("Failed to query `FinalizationPeriodSeconds` from Oracle contract accessor, error: %v", err)
-		return nil, err
+	// Initialize evidence store to archive the evidence of the diverged outputs, if enabled
+	var evidenceStore EvidenceStore
+	if faultDetectorConfig.Evidence != nil && faultDetectorConfig.Evidence.Enable {
+		evidenceStore, err = NewFileEvidenceStore(faultDetectorConfig.Evidence.Directory, faultDetector.l2ChainID)
+		if err != nil {
+			logger.Errorf("Failed to create evidence store in directory: %s, error: %v", faultDetectorConfig.Evidence.Directory, err)
+			return nil, err
+		}
 	}
 
-	logger.Infof("Fault proof window is set to %d.", finalizedPeriodSeconds)
+	resumeFromCheckpoint := checkpoint != nil && faultDetectorConfig.Checkpoint.ResumeFromCheckpoint
+	if checkpoint != nil && !resumeFromCheckpoint {
+		logger.Infof("Ignoring checkpoint saved at %s, re-deriving the starting batch index.", checkpoint.UpdatedAt)
+	}
 
 	var currentOutputIndex uint64
-	if faultDetectorConfig.StartBatchIndex == -1 {
+	var lastVerifiedIndex *uint64
+	var divergedOutputs []*DivergedOutput
+	resumed := false
+	if resumeFromCheckpoint {
+		currentOutputIndex, resumed = checkpoint.ResumeOutputIndex()
+		lastVerifiedIndex = checkpoint.LastVerifiedOutputIndex
+		divergedOutputs = checkpoint.GetDivergedOutputs()
+		if resumed {
+			logger.Infof("Resuming from checkpoint saved at %s at output index %d.", checkpoint.UpdatedAt, currentOutputIndex)
+		} else {
+			logger.Infof("Checkpoint saved at %s holds no verified output, re-deriving the starting batch index.", checkpoint.UpdatedAt)
+		}
+	}
+
+	switch {
+	case resumed:
+	case faultDetectorConfig.StartBatchIndex == -1:
 		logger.Infof("Finding appropriate starting unfinalized batch....")
 		firstUnfinalized, _ := FindFirstUnfinalizedOutputIndex(
 			ctx,
 			logger,
-			encoding.MustConvertBigIntToUint64(finalizedPeriodSeconds),
-			oracleContractAccessor,
-			l2RpcApi,
+			faultDetector.faultProofWindow,
+			faultDetector.oracleContractAccessor,
+			faultDetector.l2RpcApi,
 		)
 		if firstUnfinalized == 0 {
 			logger.Infof("No unfinalized batches found. skipping all batches.")
-			nextOutputIndex, err := oracleContractAccessor.GetNextOutputIndex()
+			nextOutputIndex, err := faultDetector.oracleContractAccessor.GetNextOutputIndex()
 			if err != nil {
 				logger.Errorf("Failed to query next output index, error: %v", err)
 				return nil, err
@@ -133,46 +323,292 @@ func NewFaultDetector(ctx context.Context, logger log.Logger, errorChan chan err
 		} else {
 			currentOutputIndex = firstUnfinalized
 		}
-	} else {
+	default:
 		currentOutputIndex = uint64(faultDetectorConfig.StartBatchIndex)
 	}
 	logger.Infof("Starting unfinalized batch index is set to %d.", currentOutputIndex)
 
-	metrics := NewFaultDetectorMetrics(metricRegistry)
-	// Initially set state mismatch to 0
-	metrics.stateMismatch.Set(0)
+	// Anyone can create a dispute game, so a game with an invalid root claim must not hold the verification of the later games
+	faultDetector.continuePastFaults = faultDetectorConfig.ContinuePastFaults || faultDetectorConfig.OracleType == chain.OracleTypeDisputeGameFactory
+
+	// Initially set state mismatch to 0, unless the checkpoint reports diverged outputs
+	faultDetector.metrics.stateMismatch.Set(0)
+	for _, divergedOutput := range divergedOutputs {
+		logger.Errorf("Checkpoint reports diverged output with index %d, expectedStateRoot: %s, calculatedStateRoot: %s.", divergedOutput.OutputIndex, divergedOutput.ExpectedOutputRoot, divergedOutput.CalculatedOutputRoot)
+		faultDetector.addDivergedOutput(divergedOutput)
+	}
+
+	// Catch-up mode is disabled when there are no workers
+	var catchUpThreshold, catchUpWindowSize uint64
+	var catchUpWorkers uint
+	if faultDetectorConfig.CatchUp != nil && faultDetectorConfig.CatchUp.Enable {
+		catchUpThreshold = faultDetectorConfig.CatchUp.Threshold
+		catchUpWindowSize = faultDetectorConfig.CatchUp.WindowSize
+		catchUpWorkers = faultDetectorConfig.CatchUp.Workers
+	}
+
+	// Query the interval the outputs are expected to be proposed at, when supported by the oracle accessor
+	var submissionInterval time.Duration
+	if reader, ok := faultDetector.oracleContractAccessor.(SubmissionIntervalReader); ok {
+		submissionIntervalSeconds, err := reader.SubmissionIntervalSeconds()
+		if err != nil {
+			logger.Errorf("Failed to query submission interval from Oracle contract accessor, error: %v", err)
+			return nil, err
+		}
+		submissionInterval = time.Duration(encoding.MustConvertBigIntToUint64(submissionIntervalSeconds)) * time.Second
+		logger.Infof("Outputs are expected to be proposed every %s.", submissionInterval)
+	}
+
+	// Wait for the new proposals for the oracle submission interval, unless configured
+	schedulerConfig := faultDetectorConfig.GetScheduler()
+	proposalInterval := schedulerConfig.ProposalInterval
+	if proposalInterval == 0 {
+		proposalInterval = submissionInterval
+	}
+	if proposalInterval == 0 {
+		proposalInterval = defaultProposalIntervalInSeconds * time.Second
+	}
+	logger.Infof("Waiting for the new proposals every %s.", proposalInterval)
+
+	// Proposer liveness is only checked when the oracle exposes the submission interval
+	var livenessIntervalMultiplier float64
+	if faultDetectorConfig.ProposerLiveness != nil && faultDetectorConfig.ProposerLiveness.Enable {
+		if submissionInterval == 0 {
+			logger.Warningf("Proposer liveness is not checked, the oracle does not expose the submission interval.")
+		} else {
+			livenessIntervalMultiplier = faultDetectorConfig.ProposerLiveness.IntervalMultiplier
+		}
+	}
+
+	// Proposer identity is only checked when the oracle accessor can look up the output proposals
+	var outputProposalReader OutputProposalReader
+	var proposerAllowlist map[common.Address]bool
+	if faultDetectorConfig.ProposerCheck != nil && faultDetectorConfig.ProposerCheck.Enable {
+		if reader, ok := faultDetector.oracleContractAccessor.(OutputProposalReader); ok {
+			outputProposalReader = reader
+			proposerAllowlist = make(map[common.Address]bool, len(faultDetectorConfig.ProposerCheck.Allowlist))
+			for _, address := range faultDetectorConfig.ProposerCheck.Allowlist {
+				proposerAllowlist[common.HexToAddress(address)] = true
+			}
+		} else {
+			logger.Warningf("Proposer identity is not checked, the oracle does not expose the output proposals.")
+		}
+	}
+
+	// Oracle parameters are only checked when the oracle accessor exposes them
+	var oracleParametersReader OracleParametersReader
+	var oracleParametersInterval time.Duration
+	if faultDetectorConfig.OracleParametersCheck != nil && faultDetectorConfig.OracleParametersCheck.Enable {
+		if reader, ok := faultDetector.oracleContractAccessor.(OracleParametersReader); ok {
+			oracleParametersReader = reader
+			oracleParametersInterval = faultDetectorConfig.OracleParametersCheck.Interval
+		} else {
+			logger.Warningf("Oracle parameters are not checked, the oracle does not expose them.")
+		}
+	}
+
+	var outputInvariants []outputInvariant
+	if faultDetectorConfig.OutputInvariantsCheck != nil && faultDetectorConfig.OutputInvariantsCheck.Enable {
+		outputInvariants = newOutputInvariants(faultDetector.oracleContractAccessor)
+	}
+
+	var escalationThresholds []*config.EscalationThreshold
+	if faultDetectorConfig.FinalizationEscalation != nil && faultDetectorConfig.FinalizationEscalation.Enable {
+		escalationThresholds = sortEscalationThresholds(faultDetectorConfig.FinalizationEscalation.Thresholds)
+	}
+
+	faultDetector.errorChan = errorChan
+	faultDetector.wg = wg
+	faultDetector.currentOutputIndex = currentOutputIndex
+	faultDetector.checkpointStore = checkpointStore
+	faultDetector.faultHistory = faultHistory
+	faultDetector.evidenceStore = evidenceStore
+	faultDetector.lastVerifiedIndex = lastVerifiedIndex
+	faultDetector.catchUpThreshold = catchUpThreshold
+	faultDetector.catchUpWindowSize = catchUpWindowSize
+	faultDetector.catchUpWorkers = catchUpWorkers
+	faultDetector.scheduler = newScheduler(schedulerConfig, proposalInterval, periodicChecksInterval(faultDetector.oracleContractAccessor, oracleParametersInterval, escalationThresholds))
+	faultDetector.submissionInterval = submissionInterval
+	faultDetector.livenessIntervalMultiplier = livenessIntervalMultiplier
+	faultDetector.outputProposalReader = outputProposalReader
+	faultDetector.proposerAllowlist = proposerAllowlist
+	faultDetector.oracleParametersReader = oracleParametersReader
+	faultDetector.oracleParametersInterval = oracleParametersInterval
+	faultDetector.outputInvariants = outputInvariants
+	faultDetector.escalationThresholds = escalationThresholds
+	faultDetector.notification = notification
+	faultDetector.setState(StateStarting)
+
+	return faultDetector, nil
+}
+
+// newFaultDetector returns [FaultDetector] with the initialized providers, oracle contract accessor and verifier, without any verification progress.
+func newFaultDetector(ctx context.Context, logger log.Logger, faultDetectorConfig *config.FaultDetectorConfig, metricRegistry prometheus.Registerer) (*FaultDetector, error) {
+	// Initialize API Providers
+	l1RpcApi, err := chain.GetAPIClient(ctx, faultDetectorConfig.L1RPCEndpoint, logger)
+	if err != nil {
+		logger.Errorf("Failed to create API client for L1 Provider with given endpoint: %s, error: %v", faultDetectorConfig.L1RPCEndpoint, err)
+		return nil, err
+	}
+
+	// The first L2 provider is used for all the L2 queries other than computing the output roots
+	var l2RpcApi *chain.ChainAPIClient
+	var l2ChainID *big.Int
+	var l2Providers []*l2Provider
+	for _, l2RPCEndpoint := range faultDetectorConfig.GetL2RPCEndpoints() {
+		l2ProviderApi, err := chain.GetAPIClient(ctx, l2RPCEndpoint, logger)
+		if err != nil {
+			logger.Errorf("Failed to create API client for L2 Provider with given endpoint: %s, error: %v", l2RPCEndpoint, err)
+			return nil, err
+		}
+
+		l2ProviderChainID, err := l2ProviderApi.GetChainID(ctx)
+		if err != nil {
+			logger.Errorf("Failed to get L2 provider's chainID with given endpoint: %s, error: %v", l2RPCEndpoint, err)
+			return nil, err
+		}
+
+		if l2RpcApi == nil {
+			l2RpcApi = l2ProviderApi
+			l2ChainID = l2ProviderChainID
+		} else if l2ProviderChainID.Cmp(l2ChainID) != 0 {
+			logger.Errorf("L2 provider with given endpoint: %s reports chainID: %d, expected: %d", l2RPCEndpoint, l2ProviderChainID, l2ChainID)
+			return nil, fmt.Errorf("l2 providers report different chainIDs")
+		}
+
+		l2Providers = append(l2Providers, &l2Provider{name: l2RPCEndpoint, client: l2ProviderApi})
+	}
+	quorum := faultDetectorConfig.GetQuorum()
+	if len(l2Providers) > 1 {
+		logger.Infof("Computing output roots with %d L2 providers and a quorum of %d.", len(l2Providers), quorum)
+	}
+
+	// Use L2 chainID as chain name when not provided
+	chainName := faultDetectorConfig.Name
+	if len(chainName) == 0 {
+		chainName = l2ChainID.String()
+	}
+	logger = logger.With("chain", chainName)
+
+	// Initialize Oracle contract accessor
+	chainConfig := &chain.ConfigOptions{
+		L1RPCEndpoint:                     faultDetectorConfig.L1RPCEndpoint,
+		ChainID:                           encoding.MustConvertBigIntToUint64(l2ChainID),
+		L2OutputOracleContractAddress:     faultDetectorConfig.L2OutputOracleContractAddress,
+		DisputeGameFactoryContractAddress: faultDetectorConfig.DisputeGameFactoryContractAddress,
+		DisputeGameType:                   faultDetectorConfig.DisputeGameType,
+		L1ReadDepth:                       faultDetectorConfig.L1ReadDepth,
+	}
+
+	var oracleContractAccessor OracleAccessor
+	if faultDetectorConfig.OracleType == chain.OracleTypeDisputeGameFactory {
+		oracleContractAccessor, err = chain.NewDisputeGameFactoryAccessor(ctx, chainConfig)
+		if err != nil {
+			logger.Errorf("Failed to create DisputeGameFactory contract accessor with chainID: %d, L1 endpoint: %s and DisputeGameFactoryContractAddress: %s, error: %v", encoding.MustConvertBigIntToUint64(l2ChainID), faultDetectorConfig.L1RPCEndpoint, faultDetectorConfig.DisputeGameFactoryContractAddress, err)
+			return nil, err
+		}
+		logger.Infof("Monitoring dispute games of type %d created by DisputeGameFactory contract %s.", faultDetectorConfig.DisputeGameType, faultDetectorConfig.DisputeGameFactoryContractAddress)
+	} else {
+		oracleContractAccessor, err = chain.NewOracleAccessor(ctx, chainConfig)
+		if err != nil {
+			logger.Errorf("Failed to create Oracle contract accessor with chainID: %d, L1 endpoint: %s and L2OutputOracleContractAddress: %s, error: %v", encoding.MustConvertBigIntToUint64(l2ChainID), faultDetectorConfig.L1RPCEndpoint, faultDetectorConfig.L2OutputOracleContractAddress, err)
+			return nil, err
+		}
+	}
+
+	finalizedPeriodSeconds, err := oracleContractAccessor.FinalizationPeriodSeconds()
+	if err != nil {
+		logger.Errorf("Failed to query `FinalizationPeriodSeconds` from Oracle contract accessor, error: %v", err)
+		return nil, err
+	}
+
+	logger.Infof("Fault proof window is set to %d.", finalizedPeriodSeconds)
+
+	// The chain name of the unnamed chains is only resolved from the L2 chainID, its uniqueness can not be validated with the config
+	metrics := newFaultDetectorMetrics()
+	if err := metrics.register(prometheus.WrapRegistererWith(prometheus.Labels{"chain": chainName}, metricRegistry)); err != nil {
+		logger.Errorf("Failed to register metrics, error: %v", err)
+		if errors.As(err, new(prometheus.AlreadyRegisteredError)) {
+			return nil, fmt.Errorf("faultdetector.name expected to be unique, received duplicate: '%s'", chainName)
+		}
+		return nil, err
+	}
+
+	// Initialize the verifier computing the output roots from the local view
+	var verifier outputVerifier = &proofVerifier{
+		ctx:       ctx,
+		logger:    logger,
+		metrics:   metrics,
+		providers: l2Providers,
+		quorum:    quorum,
+	}
+	if faultDetectorConfig.Verifier == chain.VerifierTypeRollupNode || faultDetectorConfig.Verifier == chain.VerifierTypeBoth {
+		rollupNodeClient, err := chain.GetRollupNodeClient(ctx, faultDetectorConfig.RollupNodeRPCEndpoint, logger)
+		if err != nil {
+			logger.Errorf("Failed to create API client for rollup node with given endpoint: %s, error: %v", faultDetectorConfig.RollupNodeRPCEndpoint, err)
+			return nil, err
+		}
+
+		rollupNodeVerifier := &rollupNodeVerifier{
+			ctx:     ctx,
+			logger:  logger,
+			metrics: metrics,
+			name:    faultDetectorConfig.RollupNodeRPCEndpoint,
+			client:  rollupNodeClient,
+		}
+		if faultDetectorConfig.Verifier == chain.VerifierTypeBoth {
+			verifier = &crossCheckVerifier{logger: logger, proof: verifier, rollupNode: rollupNodeVerifier}
+		} else {
+			verifier = rollupNodeVerifier
+		}
+		logger.Infof("Computing output roots with %s verifier, rollup node endpoint: %s.", faultDetectorConfig.Verifier, faultDetectorConfig.RollupNodeRPCEndpoint)
+	}
 
-	faultDetector := &FaultDetector{
+	return &FaultDetector{
 		ctx:                    ctx,
 		logger:                 logger,
-		errorChan:              errorChan,
-		wg:                     wg,
+		chainName:              chainName,
 		l1RpcApi:               l1RpcApi,
+		l1RPCEndpoint:          faultDetectorConfig.L1RPCEndpoint,
 		l2RpcApi:               l2RpcApi,
+		verifier:               verifier,
 		oracleContractAccessor: oracleContractAccessor,
 		faultProofWindow:       finalizedPeriodSeconds.Uint64(),
-		currentOutputIndex:     currentOutputIndex,
-		diverged:               false,
+		l2ChainID:              encoding.MustConvertBigIntToUint64(l2ChainID),
 		metrics:                metrics,
-		notification:           notification,
 		mutex:                  new(sync.RWMutex),
-	}
-
-	return faultDetector, nil
+	}, nil
 }
 
-// Start will start the fault detector service by invoking the service every given interval.
+// Start will start the fault detector service by invoking the service on every proposed output, or at the interval scheduled after every check when the oracle can not be subscribed to.
 func (fd *FaultDetector) Start() {
 	defer fd.wg.Done()
-	fd.logger.Infof("Started fault detector service, checking for state root every %d seconds.", serviceIntervalInSeconds)
-	fd.ticker = time.NewTicker(serviceIntervalInSeconds * time.Second)
+	fd.timer = time.NewTimer(0)
 	fd.quitTickerChan = make(chan struct{})
+	fd.proposedOutputs = make(chan chain.L2Output)
+	fd.deletedOutputs = make(chan chain.OutputsDeleted)
+	fd.outputSubscriber, _ = fd.oracleContractAccessor.(OutputSubscriber)
+	defer fd.unsubscribeOutputProposed()
+
+	if fd.subscribeOutputProposed() {
+		fd.logger.Infof("Started fault detector service, checking for state root on every proposed output and reconciling every %s.", fd.scheduler.proposalInterval)
+	} else {
+		fd.logger.Infof("Started fault detector service, checking for state root every %s while waiting for the new proposals.", fd.scheduler.proposalInterval)
+	}
 	for {
 		select {
-		case <-fd.ticker.C:
-			if err := fd.checkFault(); err != nil {
-				time.Sleep(waitTimeInFailure * time.Second)
-			}
+		case <-fd.timer.C:
+			fd.resubscribeOutputProposed()
+			fd.runCheck()
+		case output := <-fd.proposedOutputs:
+			fd.logger.Infof("Received proposed output with index: %d and L2 block number: %d.", output.L2OutputIndex, output.L2BlockNumber)
+			fd.awaitedOutput = &output
+			fd.runCheck()
+		case deletion := <-fd.deletedOutputs:
+			fd.logger.Infof("Received deletion of outputs with index from %d to %d.", deletion.NewNextOutputIndex, deletion.PrevNextOutputIndex-1)
+			fd.runCheck()
+		case err := <-fd.outputSubscriptionErr():
+			fd.handleOutputSubscriptionError(err)
 		case <-fd.quitTickerChan:
 			fd.logger.Infof("Quit ticker for periodic fault detection.")
 			return
@@ -180,127 +616,414 @@ func (fd *FaultDetector) Start() {
 	}
 }
 
-// Stop will stop the ticker.
+// runCheck checks for the faults and schedules the next check based on the outcome of the check.
+// Outputs are checked right away while behind the oracle, every proposal interval while waiting for the new proposals and with exponential backoff on failures.
+func (fd *FaultDetector) runCheck() {
+	diverged, err := fd.checkFault()
+	outcome := classifyCheck(err, diverged)
+	fd.setState(stateFromOutcome(outcome))
+	interval := fd.nextCheckInterval(outcome)
+	fd.logger.Debugf("Scheduling next check in %s, last check outcome: %s.", interval, outcome)
+	fd.scheduleCheck(interval)
+}
+
+// nextCheckInterval returns the interval until the next check after a check with the given outcome.
+// While the proposed output received from the subscription is not yet visible to the oracle reads pinned at the L1 read depth, it is checked again after the L2 node behind interval instead of the proposal interval.
+func (fd *FaultDetector) nextCheckInterval(outcome checkOutcome) time.Duration {
+	interval := fd.scheduler.next(outcome)
+	if fd.awaitingProposedOutput(outcome) {
+		return min(interval, fd.scheduler.l2NodeBehindInterval)
+	}
+	return interval
+}
+
+// scheduleCheck schedules the next check after the given interval, replacing any check scheduled before.
+func (fd *FaultDetector) scheduleCheck(interval time.Duration) {
+	if !fd.timer.Stop() {
+		select {
+		case <-fd.timer.C:
+		default:
+		}
+	}
+	fd.timer.Reset(interval)
+}
+
+// Stop will stop the scheduled checks.
 func (fd *FaultDetector) Stop() {
-	fd.ticker.Stop()
+	fd.timer.Stop()
 	close(fd.quitTickerChan)
 	fd.logger.Infof("Successfully stopped fault detector service.")
 }
 
+// outputVerification holds the result of verifying a single L2 output against the local view.
+type outputVerification struct {
+	outputIndex          uint64
+	l2BlockNumber        uint64
+	l1Timestamp          uint64
+	expectedOutputRoot   string
+	calculatedOutputRoot string
+	finalizationTime     time.Time
+	elapsedTime          time.Duration
+	nodeInconsistency    *nodeInconsistency
+	proposal             *chain.OutputProposal
+	expectedProposer     common.Address
+	invariantViolations  []*invariantViolation
+	header               *types.Header
+	proof                *chain.ProofResponse
+	endpoints            []string
+	l1Block              *types.Header
+	unmonitored          bool
+}
+
+// isMatched returns true when the calculated output root matches the one published to the oracle.
+func (v *outputVerification) isMatched() bool {
+	return v.calculatedOutputRoot == v.expectedOutputRoot
+}
+
 // checkFault continuously checks for the faults at regular interval.
-func (fd *FaultDetector) checkFault() error {
-	startTime := time.Now()
-	fd.logger.Infof("Checking current batch with output index: %d.", fd.currentOutputIndex)
+// It returns true when an output verified by this check diverged, the diverged outputs reported by the earlier checks are not accounted for.
+func (fd *FaultDetector) checkFault() (bool, error) {
+	// Escalate independently of the L1 provider availability, the finalization deadline does not wait
+	fd.refreshDiverged()
+	fd.checkFinalizationEscalation()
+
+	// Pin all the oracle reads of this iteration to the same L1 block
+	if err := fd.pinL1Block(); err != nil {
+		return false, err
+	}
+
+	fd.checkOracleParameters()
+	fd.checkDisputeGames()
 
 	nextOutputIndex, err := fd.oracleContractAccessor.GetNextOutputIndex()
 	if err != nil {
 		fd.logger.Errorf("Failed to query next output index, error: %v.", err)
 		fd.metrics.apiConnectionFailure.Inc()
-		return err
+		return false, err
+	}
+
+	if err := fd.checkDeletedOutputs(encoding.MustConvertBigIntToUint64(nextOutputIndex)); err != nil {
+		return false, err
+	}
+
+	if err := fd.checkReorgedOutputs(encoding.MustConvertBigIntToUint64(nextOutputIndex)); err != nil {
+		return false, err
+	}
+
+	if err := fd.checkProposerLiveness(encoding.MustConvertBigIntToUint64(nextOutputIndex)); err != nil {
+		return false, err
 	}
 
+	fd.recheckDivergedOutputs()
+
 	latestBatchIndex := encoding.MustConvertBigIntToUint64(nextOutputIndex) - 1
 	fd.logger.Infof("Latest batch index is set to %d.", latestBatchIndex)
 	if fd.currentOutputIndex > latestBatchIndex {
 		fd.logger.Infof("Current output index %d is ahead of the oracle latest batch index %d. Waiting...", fd.currentOutputIndex, latestBatchIndex)
-		return fmt.Errorf("current output index is ahead of the oracle latest batch index")
+		return false, errNoNewOutput
+	}
+
+	if fd.catchUpWorkers > 0 && latestBatchIndex-fd.currentOutputIndex >= fd.catchUpThreshold {
+		return fd.catchUp(latestBatchIndex)
 	}
 
-	l2OutputData, err := fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(fd.currentOutputIndex))
+	fd.logger.Infof("Checking current batch with output index: %d.", fd.currentOutputIndex)
+	verification, err := fd.verifyOutput(fd.currentOutputIndex)
 	if err != nil {
-		fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", fd.currentOutputIndex, err)
-		fd.metrics.apiConnectionFailure.Inc()
-		return err
+		fd.handleVerificationError(err)
+		return false, err
+	}
+
+	fd.commitVerification(verification)
+	return !verification.isMatched(), nil
+}
+
+// catchUp verifies a window of outputs, starting at the current output index, concurrently with a bounded worker pool.
+// The results are committed strictly in order, stopping at the first failed output, or at the first diverged output unless the scan continues past the faults.
+// While the scan is held at a diverged output, only the diverged output is verified again, the later outputs are verified once it matches.
+// It returns true when any of the committed outputs diverged.
+func (fd *FaultDetector) catchUp(latestBatchIndex uint64) (bool, error) {
+	windowSize := latestBatchIndex - fd.currentOutputIndex + 1
+	if windowSize > fd.catchUpWindowSize {
+		windowSize = fd.catchUpWindowSize
+	}
+	if fd.isHeldAtDivergedOutput() {
+		windowSize = 1
+	}
+	startIndex := fd.currentOutputIndex
+	fd.logger.Infof("Catching up with the oracle latest batch index %d, checking batches with output index from %d to %d using %d workers.", latestBatchIndex, startIndex, startIndex+windowSize-1, fd.catchUpWorkers)
+
+	verifications := make([]*outputVerification, windowSize)
+	errs := make([]error, windowSize)
+	offsets := make(chan uint64)
+	var wg sync.WaitGroup
+	for i := uint(0); i < fd.catchUpWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for offset := range offsets {
+				verifications[offset], errs[offset] = fd.verifyOutput(startIndex + offset)
+			}
+		}()
 	}
+	for offset := uint64(0); offset < windowSize; offset++ {
+		offsets <- offset
+	}
+	close(offsets)
+	wg.Wait()
+
+	diverged := false
+	for offset := uint64(0); offset < windowSize; offset++ {
+		if errs[offset] != nil {
+			fd.handleVerificationError(errs[offset])
+			return diverged, errs[offset]
+		}
+		fd.commitVerification(verifications[offset])
+		if !verifications[offset].isMatched() {
+			diverged = true
+			if !fd.continuePastFaults {
+				return diverged, nil
+			}
+		}
+	}
+
+	return diverged, nil
+}
+
+// isHeldAtDivergedOutput returns true when the current output is diverged and the scan does not continue past the faults.
+func (fd *FaultDetector) isHeldAtDivergedOutput() bool {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	_, ok := fd.divergedOutputs[fd.currentOutputIndex]
+	return ok && !fd.continuePastFaults
+}
 
-	latestBlockNumber, err := fd.l2RpcApi.GetLatestBlockNumber(fd.ctx)
+// verifyOutput computes the output root for the given output index from the local view and compares it with the one published to the oracle.
+// It does not mutate the fault detector state, so it is safe to be invoked concurrently.
+func (fd *FaultDetector) verifyOutput(outputIndex uint64) (*outputVerification, error) {
+	startTime := time.Now()
+
+	l2OutputData, err := fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(outputIndex))
 	if err != nil {
-		fd.logger.Errorf("Failed to query L2 latest block number: %d, error: %v", latestBlockNumber, err)
+		fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", outputIndex, err)
 		fd.metrics.apiConnectionFailure.Inc()
-		return err
+		return nil, err
+	}
+	if l2OutputData.Unmonitored {
+		return &outputVerification{
+			outputIndex: outputIndex,
+			l1Timestamp: l2OutputData.L1Timestamp,
+			unmonitored: true,
+			elapsedTime: time.Since(startTime),
+		}, nil
 	}
 
 	l2OutputBlockNumber := l2OutputData.L2BlockNumber
-	expectedOutputRoot := l2OutputData.OutputRoot
-	if latestBlockNumber < l2OutputBlockNumber {
-		fd.logger.Infof("L2 node is behind, waiting for node to sync with the network...")
-		return fmt.Errorf("l2 node is behind")
+	output, inconsistency, err := fd.verifier.computeOutputRoot(outputIndex, l2OutputBlockNumber)
+	if err != nil {
+		return nil, err
 	}
 
-	outputBlockHeader, err := fd.l2RpcApi.GetBlockHeaderByNumber(fd.ctx, encoding.MustConvertUint64ToBigInt(l2OutputBlockNumber))
+	invariantViolations, err := fd.checkOutputInvariants(l2OutputData, output.blockTimestamp)
 	if err != nil {
-		fd.logger.Errorf("Failed to fetch block header by number: %d, error: %v.", l2OutputBlockNumber, err)
-		fd.metrics.apiConnectionFailure.Inc()
-		return err
+		return nil, err
 	}
 
-	messagePasserProofResponse, err := fd.l2RpcApi.GetProof(fd.ctx, encoding.MustConvertUint64ToBigInt(l2OutputBlockNumber), common.HexToAddress(chain.L2BedrockMessagePasserAddress))
-	if err != nil {
-		fd.logger.Errorf("Failed to fetch message passer proof for the block with height: %d and address: %s, error: %v.", l2OutputBlockNumber, chain.L2BedrockMessagePasserAddress, err)
-		fd.metrics.apiConnectionFailure.Inc()
-		return err
-	}
-
-	calculatedOutputRoot := encoding.ComputeL2OutputRoot(
-		outputBlockHeader.Root,
-		messagePasserProofResponse.StorageHash,
-		outputBlockHeader.Hash(),
-	)
-	if calculatedOutputRoot != expectedOutputRoot {
-		fd.mutex.Lock()
-		fd.diverged = true
-		fd.mutex.Unlock()
-
-		fd.metrics.stateMismatch.Set(1)
-		finalizationTime := time.Unix(int64(outputBlockHeader.Time+fd.faultProofWindow), 0)
-
-		if fd.notification != nil {
-			notificationMessage := fmt.Sprintf("*Fault detected*, state root does not match:\noutputIndex: %d\nExpectedStateRoot: %s\nCalculatedStateRoot: %s\nFinalizationTime: %s", fd.currentOutputIndex, expectedOutputRoot, calculatedOutputRoot, finalizationTime)
-			if err := fd.notification.Notify(notificationMessage); err != nil {
-				fd.logger.Errorf("Error while sending notification, %v", err)
-			}
+	verification := &outputVerification{
+		outputIndex:          outputIndex,
+		l2BlockNumber:        l2OutputBlockNumber,
+		l1Timestamp:          l2OutputData.L1Timestamp,
+		expectedOutputRoot:   l2OutputData.OutputRoot,
+		calculatedOutputRoot: output.outputRoot,
+		finalizationTime:     finalizationTime(l2OutputData.L1Timestamp, fd.faultProofWindow),
+		nodeInconsistency:    inconsistency,
+		invariantViolations:  invariantViolations,
+		header:               output.header,
+		proof:                output.proof,
+		endpoints:            output.endpoints,
+		l1Block:              fd.pinnedL1Block,
+	}
+
+	if fd.outputProposalReader != nil {
+		verification.proposal, err = fd.outputProposalReader.GetOutputProposal(fd.ctx, outputIndex, l2OutputData.OutputRoot, l2OutputData.L1Timestamp)
+		if err != nil {
+			fd.logger.Errorf("Failed to fetch proposal of output with index: %d, error: %v.", outputIndex, err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return nil, err
+		}
+
+		verification.expectedProposer, err = fd.outputProposalReader.Proposer()
+		if err != nil {
+			fd.logger.Errorf("Failed to query proposer from Oracle contract accessor, error: %v.", err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return nil, err
 		}
+	}
 
-		fd.logger.Errorf("State root does not match expectedStateRoot: %s, calculatedStateRoot: %s, finalizationTime: %s.", expectedOutputRoot, calculatedOutputRoot, finalizationTime)
-		return nil
+	verification.elapsedTime = time.Since(startTime)
+	return verification, nil
+}
+
+// commitVerification updates the fault detector state with the result of a verified output.
+// The current output index is only advanced when the output root matches, or when the scan continues past the faults.
+// The unmonitored outputs are skipped without being verified.
+func (fd *FaultDetector) commitVerification(verification *outputVerification) {
+	if verification.unmonitored {
+		fd.logger.Infof("Skipping batch with index %d, the dispute game type is not monitored.", verification.outputIndex)
+		fd.metrics.highestOutputIndex.Set(float64(verification.outputIndex))
+		fd.setLastVerifiedIndex(verification.outputIndex)
+		fd.currentOutputIndex = verification.outputIndex + 1
+		fd.saveCheckpoint()
+		return
 	}
 
-	fd.metrics.highestOutputIndex.Set(float64(fd.currentOutputIndex))
+	fd.reportNodeInconsistency(verification.nodeInconsistency)
+	fd.checkProposer(verification)
+	fd.reportInvariantViolations(verification)
+
+	if !verification.isMatched() {
+		fd.addDivergedOutput(&DivergedOutput{
+			OutputIndex:          verification.outputIndex,
+			L2BlockNumber:        verification.l2BlockNumber,
+			L1Timestamp:          verification.l1Timestamp,
+			ExpectedOutputRoot:   verification.expectedOutputRoot,
+			CalculatedOutputRoot: verification.calculatedOutputRoot,
+			FinalizationTime:     verification.finalizationTime,
+		})
+		if fd.continuePastFaults {
+			fd.metrics.highestOutputIndex.Set(float64(verification.outputIndex))
+			fd.setLastVerifiedIndex(verification.outputIndex)
+			fd.currentOutputIndex = verification.outputIndex + 1
+		}
+		fd.saveCheckpoint()
+		fd.recordFault(verification)
+		fd.saveEvidence(verification)
+		fd.trackDisputeGame(verification)
+
+		fd.notify(fmt.Sprintf("*Fault detected*, state root does not match:\noutputIndex: %d\nExpectedStateRoot: %s\nCalculatedStateRoot: %s\nFinalizationTime: %s", verification.outputIndex, verification.expectedOutputRoot, verification.calculatedOutputRoot, verification.finalizationTime))
+
+		fd.logger.Errorf("State root does not match expectedStateRoot: %s, calculatedStateRoot: %s, finalizationTime: %s.", verification.expectedOutputRoot, verification.calculatedOutputRoot, verification.finalizationTime)
+		return
+	}
+
+	fd.metrics.highestOutputIndex.Set(float64(verification.outputIndex))
 
 	// Time taken to execute each batch in milliseconds.
-	elapsedTime := time.Since(startTime).Milliseconds()
-	fd.logger.Infof("Successfully checked current batch with index %d --> ok, time taken %dms.", fd.currentOutputIndex, elapsedTime)
-	fd.mutex.Lock()
-	fd.diverged = false
-	fd.mutex.Unlock()
-
-	fd.currentOutputIndex++
-	fd.metrics.stateMismatch.Set(0)
-	return nil
+	fd.logger.Infof("Successfully checked current batch with index %d --> ok, time taken %dms.", verification.outputIndex, verification.elapsedTime.Milliseconds())
+	fd.removeDivergedOutputs(verification.outputIndex, verification.outputIndex)
+
+	fd.setLastVerifiedIndex(verification.outputIndex)
+	fd.lastVerifiedOutput = &verifiedOutput{outputIndex: verification.outputIndex, outputRoot: verification.expectedOutputRoot}
+	fd.currentOutputIndex = verification.outputIndex + 1
+	fd.saveCheckpoint()
+	fd.resolveFaults(verification.outputIndex, verification.outputIndex, FaultResolutionVerified)
+	fd.trackDisputeGame(verification)
+}
+
+// handleVerificationError reports the disagreement between the L2 providers or the verifiers, when the output could not be verified due to it.
+func (fd *FaultDetector) handleVerificationError(err error) {
+	var qErr *quorumError
+	if errors.As(err, &qErr) {
+		fd.reportNodeInconsistency(qErr.inconsistency)
+	}
+	var mErr *verifierMismatchError
+	if errors.As(err, &mErr) {
+		fd.reportNodeInconsistency(mErr.inconsistency)
+	}
+}
+
+// notify sends the message to the notification channels, if the notification service is enabled.
+func (fd *FaultDetector) notify(msg string) {
+	fd.notifySlackChannel("", msg)
+}
+
+// notifySlackChannel sends the message to the given slack channel, or to the configured channels when empty.
+func (fd *FaultDetector) notifySlackChannel(slackChannelID string, msg string) {
+	if fd.notification == nil {
+		return
+	}
+
+	if len(fd.chainName) > 0 {
+		msg = fmt.Sprintf("[%s] %s", fd.chainName, msg)
+	}
+
+	if err := fd.notification.NotifySlackChannel(slackChannelID, msg); err != nil {
+		fd.logger.Errorf("Error while sending notification, %v", err)
+	}
+}
+
+// setLastVerifiedIndex records the index of the last verified output, saved in the checkpoint.
+func (fd *FaultDetector) setLastVerifiedIndex(outputIndex uint64) {
+	fd.lastVerifiedIndex = &outputIndex
+}
+
+// rewindLastVerifiedIndex records the output before the given index as the last verified output, or none when rewinding to the first output.
+func (fd *FaultDetector) rewindLastVerifiedIndex(outputIndex uint64) {
+	if outputIndex == 0 {
+		fd.lastVerifiedIndex = nil
+		return
+	}
+	fd.setLastVerifiedIndex(outputIndex - 1)
+}
+
+// saveCheckpoint persists the current progress to the checkpoint store, if enabled.
+func (fd *FaultDetector) saveCheckpoint() {
+	if fd.checkpointStore == nil {
+		return
+	}
+
+	fd.mutex.RLock()
+	checkpoint := &Checkpoint{
+		ChainID:                 fd.l2ChainID,
+		LastVerifiedOutputIndex: fd.lastVerifiedIndex,
+		Diverged:                fd.diverged,
+		DivergedOutput:          fd.divergedOutput,
+		UpdatedAt:               time.Now(),
+	}
+	fd.mutex.RUnlock()
+	if fd.continuePastFaults {
+		checkpoint.DivergedOutputs = fd.DivergedOutputs()
+	}
+
+	if err := fd.checkpointStore.Save(checkpoint); err != nil {
+		fd.logger.Errorf("Failed to save checkpoint with current output index %d, error: %v", fd.currentOutputIndex, err)
+	}
+}
+
+// ChainName returns name of the chain monitored by the fault detector.
+func (fd *FaultDetector) ChainName() string {
+	return fd.chainName
 }
 
 // IsFaultDetected returns status of the fault detector.
 func (fd *FaultDetector) IsFaultDetected() bool {
 	fd.mutex.RLock()
 	defer fd.mutex.RUnlock()
-	return fd.diverged
+	return fd.isFaultDetectedLocked()
 }
 
 // GetFaultDetector create [FaultDetector] instance from input values.
 func GetFaultDetector(ctx context.Context, logger log.Logger, l1RpcApi *chain.ChainAPIClient, l2RpcApi *chain.ChainAPIClient, oracleContractAccessor OracleAccessor, faultProofWindow uint64, currentOutputIndex uint64, metrics *faultDetectorMetrics, notification *notification.Notification, diverged bool, wg *sync.WaitGroup, errorChan chan error, mutex *sync.RWMutex) *FaultDetector {
-	return &FaultDetector{
+	fd := &FaultDetector{
 		ctx:                    ctx,
 		logger:                 logger,
 		errorChan:              errorChan,
 		wg:                     wg,
 		l1RpcApi:               l1RpcApi,
 		l2RpcApi:               l2RpcApi,
+		verifier:               &proofVerifier{ctx: ctx, logger: logger, metrics: metrics, providers: []*l2Provider{{name: "l2", client: l2RpcApi}}, quorum: 1},
 		oracleContractAccessor: oracleContractAccessor,
 		faultProofWindow:       faultProofWindow,
 		currentOutputIndex:     currentOutputIndex,
 		diverged:               diverged,
 		metrics:                metrics,
+		faultHistory:           &faultHistory{},
+		scheduler:              newScheduler(new(config.FaultDetectorConfig).GetScheduler(), defaultProposalIntervalInSeconds*time.Second, 0),
 		notification:           notification,
 		mutex:                  mutex,
 	}
+	fd.setState(StateStarting)
+
+	return fd
 }
@@ -0,0 +1,369 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/chain/chaintest"
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCheckpointStore is a [CheckpointStore] keeping every saved checkpoint, in the order they were saved.
+type recordingCheckpointStore struct {
+	checkpoints []*Checkpoint
+}
+
+func (s *recordingCheckpointStore) Load() (*Checkpoint, error) {
+	if len(s.checkpoints) == 0 {
+		return nil, nil
+	}
+	return s.checkpoints[len(s.checkpoints)-1], nil
+}
+
+func (s *recordingCheckpointStore) Save(checkpoint *Checkpoint) error {
+	s.checkpoints = append(s.checkpoints, checkpoint)
+	return nil
+}
+
+func TestCatchUp(t *testing.T) {
+	tests := []struct {
+		name                       string
+		latestBatchIndex           uint64
+		continuePastFaults         bool
+		heldAtDivergedOutput       bool
+		mismatched                 map[uint64]bool
+		failed                     map[uint64]bool
+		delayed                    map[uint64]bool
+		expectedVerifiedIndexes    []uint64
+		expectedCommittedIndexes   []uint64
+		expectedCurrentOutputIndex uint64
+		expectedDiverged           bool
+		expectedErr                bool
+	}{
+		{
+			name:                       "should verify the window with the worker pool and commit every output in order",
+			latestBatchIndex:           30,
+			expectedVerifiedIndexes:    []uint64{10, 11, 12, 13, 14},
+			expectedCommittedIndexes:   []uint64{10, 11, 12, 13, 14},
+			expectedCurrentOutputIndex: 15,
+		},
+		{
+			name:                       "should bound the window by the latest batch index",
+			latestBatchIndex:           12,
+			expectedVerifiedIndexes:    []uint64{10, 11, 12},
+			expectedCommittedIndexes:   []uint64{10, 11, 12},
+			expectedCurrentOutputIndex: 13,
+		},
+		{
+			name:                       "should commit in order when the later outputs are verified first",
+			latestBatchIndex:           30,
+			delayed:                    map[uint64]bool{10: true, 11: true},
+			expectedVerifiedIndexes:    []uint64{10, 11, 12, 13, 14},
+			expectedCommittedIndexes:   []uint64{10, 11, 12, 13, 14},
+			expectedCurrentOutputIndex: 15,
+		},
+		{
+			name:                       "should stop at the first failed output",
+			latestBatchIndex:           30,
+			failed:                     map[uint64]bool{12: true, 13: true},
+			expectedVerifiedIndexes:    []uint64{10, 11, 12, 13, 14},
+			expectedCommittedIndexes:   []uint64{10, 11},
+			expectedCurrentOutputIndex: 12,
+			expectedErr:                true,
+		},
+		{
+			name:                       "should stop at the first diverged output",
+			latestBatchIndex:           30,
+			mismatched:                 map[uint64]bool{12: true, 13: true},
+			expectedVerifiedIndexes:    []uint64{10, 11, 12, 13, 14},
+			expectedCommittedIndexes:   []uint64{10, 11, 11},
+			expectedCurrentOutputIndex: 12,
+			expectedDiverged:           true,
+		},
+		{
+			name:                       "should commit past the diverged output when the scan continues past the faults",
+			latestBatchIndex:           30,
+			continuePastFaults:         true,
+			mismatched:                 map[uint64]bool{12: true},
+			expectedVerifiedIndexes:    []uint64{10, 11, 12, 13, 14},
+			expectedCommittedIndexes:   []uint64{10, 11, 12, 13, 14},
+			expectedCurrentOutputIndex: 15,
+			expectedDiverged:           true,
+		},
+		{
+			name:                       "should only verify the diverged output while the scan is held at it",
+			latestBatchIndex:           30,
+			heldAtDivergedOutput:       true,
+			mismatched:                 map[uint64]bool{10: true},
+			expectedVerifiedIndexes:    []uint64{10},
+			expectedCommittedIndexes:   []uint64{9},
+			expectedCurrentOutputIndex: 10,
+			expectedDiverged:           true,
+		},
+		{
+			name:                       "should verify the window again once the diverged output the scan is held at matches",
+			latestBatchIndex:           30,
+			heldAtDivergedOutput:       true,
+			expectedVerifiedIndexes:    []uint64{10},
+			expectedCommittedIndexes:   []uint64{10},
+			expectedCurrentOutputIndex: 11,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			oracle := new(mockOracleAccessor)
+			verifier := new(mockOutputVerifier)
+			for index := uint64(0); index <= test.latestBatchIndex; index++ {
+				outputIndex := index
+				l2BlockNumber := (outputIndex + 1) * 100
+				outputRoot := randHash().String()
+				oracle.On("GetL2Output", mock.MatchedBy(func(i *big.Int) bool { return i.Uint64() == outputIndex })).Return(chain.L2Output{
+					OutputRoot:    outputRoot,
+					L2BlockNumber: l2BlockNumber,
+				}, nil)
+
+				calculatedOutputRoot := outputRoot
+				if test.mismatched[outputIndex] {
+					calculatedOutputRoot = randHash().String()
+				}
+				var err error
+				if test.failed[outputIndex] {
+					err = fmt.Errorf("Failed to compute output root")
+				}
+				call := verifier.On("computeOutputRoot", outputIndex, l2BlockNumber).Return(&providerOutput{outputRoot: calculatedOutputRoot, blockTimestamp: uint64(time.Now().Unix())}, err)
+				if test.delayed[outputIndex] {
+					call.After(50 * time.Millisecond)
+				}
+			}
+
+			checkpointStore := &recordingCheckpointStore{}
+			fd := &FaultDetector{
+				ctx:                    context.Background(),
+				logger:                 logger,
+				metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				mutex:                  new(sync.RWMutex),
+				oracleContractAccessor: oracle,
+				verifier:               verifier,
+				checkpointStore:        checkpointStore,
+				faultProofWindow:       3600,
+				lastVerifiedIndex:      uint64Ptr(9),
+				currentOutputIndex:     10,
+				catchUpWindowSize:      5,
+				catchUpWorkers:         3,
+				continuePastFaults:     test.continuePastFaults,
+			}
+			if test.heldAtDivergedOutput {
+				fd.addDivergedOutput(&DivergedOutput{OutputIndex: 10, FinalizationTime: time.Now().Add(time.Hour)})
+			}
+
+			diverged, err := fd.catchUp(test.latestBatchIndex)
+			if test.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectedDiverged, diverged)
+
+			verifiedIndexes := []uint64{}
+			for _, call := range verifier.Calls {
+				verifiedIndexes = append(verifiedIndexes, call.Arguments.Get(0).(uint64))
+			}
+			sort.Slice(verifiedIndexes, func(i, j int) bool { return verifiedIndexes[i] < verifiedIndexes[j] })
+			require.Equal(t, test.expectedVerifiedIndexes, verifiedIndexes)
+
+			committedIndexes := []uint64{}
+			for _, checkpoint := range checkpointStore.checkpoints {
+				committedIndexes = append(committedIndexes, *checkpoint.LastVerifiedOutputIndex)
+			}
+			require.Equal(t, test.expectedCommittedIndexes, committedIndexes)
+			require.Equal(t, test.expectedCurrentOutputIndex, fd.currentOutputIndex)
+		})
+	}
+}
+
+func TestCheckFault_ContinuePastFaults(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	oracle := new(mockOracleAccessor)
+	verifier := new(mockOutputVerifier)
+	oracle.On("GetNextOutputIndex").Return(big.NewInt(12), nil)
+	for _, outputIndex := range []uint64{5, 10, 11} {
+		index := outputIndex
+		oracle.On("GetL2Output", mock.MatchedBy(func(i *big.Int) bool { return i.Uint64() == index })).Return(chain.L2Output{
+			OutputRoot:    fmt.Sprintf("0x%02x", index),
+			L2BlockNumber: (index + 1) * 100,
+		}, nil)
+	}
+	// The output with index 5 diverged on an earlier check and still diverges, as does the output with index 11
+	verifier.On("computeOutputRoot", uint64(5), uint64(600)).Return(&providerOutput{outputRoot: randHash().String(), blockTimestamp: uint64(time.Now().Unix())}, nil)
+	verifier.On("computeOutputRoot", uint64(10), uint64(1100)).Return(&providerOutput{outputRoot: "0x0a", blockTimestamp: uint64(time.Now().Unix())}, nil)
+	verifier.On("computeOutputRoot", uint64(11), uint64(1200)).Return(&providerOutput{outputRoot: randHash().String(), blockTimestamp: uint64(time.Now().Unix())}, nil)
+
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		oracleContractAccessor: oracle,
+		verifier:               verifier,
+		faultHistory:           &faultHistory{},
+		faultProofWindow:       3600,
+		currentOutputIndex:     10,
+		continuePastFaults:     true,
+	}
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, FinalizationTime: time.Now().Add(time.Hour)})
+
+	// The earlier fault is still reported, but the check verifying a matching output is not a diverged check
+	diverged, err := fd.checkFault()
+	require.NoError(t, err)
+	require.False(t, diverged)
+	require.True(t, fd.IsFaultDetected())
+	require.Equal(t, outcomeBehind, classifyCheck(err, diverged))
+
+	diverged, err = fd.checkFault()
+	require.NoError(t, err)
+	require.True(t, diverged)
+	require.Equal(t, outcomeDiverged, classifyCheck(err, diverged))
+
+	_, err = fd.checkFault()
+	require.ErrorIs(t, err, errNoNewOutput)
+	require.Equal(t, []uint64{5, 11}, []uint64{fd.DivergedOutputs()[0].OutputIndex, fd.DivergedOutputs()[1].OutputIndex})
+}
+
+func TestNewFaultDetector_ResumeFromCheckpoint(t *testing.T) {
+	// The chainID has no known oracle address, the fake oracle address is used instead
+	const l2ChainID uint64 = 901
+
+	tests := []struct {
+		name                       string
+		checkpoint                 *Checkpoint
+		expectedCurrentOutputIndex uint64
+		expectedLastVerifiedIndex  *uint64
+		expectedDivergedIndexes    []uint64
+	}{
+		{
+			name:                       "should resume after the last verified output",
+			checkpoint:                 &Checkpoint{ChainID: l2ChainID, LastVerifiedOutputIndex: uint64Ptr(2)},
+			expectedCurrentOutputIndex: 3,
+			expectedLastVerifiedIndex:  uint64Ptr(2),
+			expectedDivergedIndexes:    []uint64{},
+		},
+		{
+			name:                       "should resume after the first output when it is the last verified output",
+			checkpoint:                 &Checkpoint{ChainID: l2ChainID, LastVerifiedOutputIndex: uint64Ptr(0)},
+			expectedCurrentOutputIndex: 1,
+			expectedLastVerifiedIndex:  uint64Ptr(0),
+			expectedDivergedIndexes:    []uint64{},
+		},
+		{
+			name:                       "should start from the start batch index when no output was verified",
+			checkpoint:                 &Checkpoint{ChainID: l2ChainID},
+			expectedCurrentOutputIndex: 0,
+			expectedDivergedIndexes:    []uint64{},
+		},
+		{
+			name: "should resume at the diverged output when no output was verified",
+			checkpoint: &Checkpoint{
+				ChainID:        l2ChainID,
+				Diverged:       true,
+				DivergedOutput: &DivergedOutput{OutputIndex: 0, FinalizationTime: time.Now().Add(time.Hour)},
+			},
+			expectedCurrentOutputIndex: 0,
+			expectedDivergedIndexes:    []uint64{0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger, _ := log.NewDefaultProductionLogger()
+			oracleAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+			genesisTime := uint64(time.Now().Add(-time.Hour).Unix())
+			l1 := chaintest.NewL1(chaintest.L1Config{
+				ChainID:     900,
+				GenesisTime: genesisTime,
+				BlockTime:   12,
+				Oracle: chaintest.OracleConfig{
+					Address:                   oracleAddress,
+					StartingTimestamp:         genesisTime,
+					SubmissionInterval:        10,
+					L2BlockTime:               2,
+					FinalizationPeriodSeconds: 3600,
+				},
+			})
+			defer l1.Close()
+			l2 := chaintest.NewL2(chaintest.L2Config{ChainID: l2ChainID, GenesisTime: genesisTime, BlockTime: 2})
+			defer l2.Close()
+
+			directory := t.TempDir()
+			store, err := NewFileCheckpointStore(directory, l2ChainID)
+			require.NoError(t, err)
+			require.NoError(t, store.Save(test.checkpoint))
+
+			fd, err := NewFaultDetector(ctx, logger, make(chan error, 1), &sync.WaitGroup{}, &config.FaultDetectorConfig{
+				L1RPCEndpoint:                 l1.URL(),
+				L2RPCEndpoints:                []string{l2.URL()},
+				StartBatchIndex:               0,
+				L2OutputOracleContractAddress: oracleAddress.Hex(),
+				Checkpoint:                    &config.Checkpoint{Enable: true, Directory: directory, ResumeFromCheckpoint: true},
+			}, prometheus.NewRegistry(), nil)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expectedCurrentOutputIndex, fd.currentOutputIndex)
+			require.Equal(t, test.expectedLastVerifiedIndex, fd.lastVerifiedIndex)
+			divergedIndexes := []uint64{}
+			for _, divergedOutput := range fd.DivergedOutputs() {
+				divergedIndexes = append(divergedIndexes, divergedOutput.OutputIndex)
+			}
+			require.Equal(t, test.expectedDivergedIndexes, divergedIndexes)
+		})
+	}
+}
+
+func TestNewFaultDetector_DuplicateChainName(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := log.NewDefaultProductionLogger()
+	oracleAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	genesisTime := uint64(time.Now().Add(-time.Hour).Unix())
+	l1 := chaintest.NewL1(chaintest.L1Config{
+		ChainID:     900,
+		GenesisTime: genesisTime,
+		BlockTime:   12,
+		Oracle: chaintest.OracleConfig{
+			Address:                   oracleAddress,
+			StartingTimestamp:         genesisTime,
+			SubmissionInterval:        10,
+			L2BlockTime:               2,
+			FinalizationPeriodSeconds: 3600,
+		},
+	})
+	defer l1.Close()
+	l2 := chaintest.NewL2(chaintest.L2Config{ChainID: 901, GenesisTime: genesisTime, BlockTime: 2})
+	defer l2.Close()
+
+	faultDetectorConfig := &config.FaultDetectorConfig{
+		L1RPCEndpoint:                 l1.URL(),
+		L2RPCEndpoints:                []string{l2.URL()},
+		L2OutputOracleContractAddress: oracleAddress.Hex(),
+	}
+	reg := prometheus.NewRegistry()
+
+	_, err := NewFaultDetector(ctx, logger, make(chan error, 1), &sync.WaitGroup{}, faultDetectorConfig, reg, nil)
+	require.NoError(t, err)
+
+	// Both unnamed entries resolve to the name of the L2 chainID
+	_, err = NewFaultDetector(ctx, logger, make(chan error, 1), &sync.WaitGroup{}, faultDetectorConfig, reg, nil)
+	require.EqualError(t, err, "faultdetector.name expected to be unique, received duplicate: '901'")
+}
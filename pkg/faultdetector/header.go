@@ -0,0 +1,96 @@
+package faultdetector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errHeaderIntegrity is returned when an L2 provider serves a block header that is not consistent with the chain it attests to.
+// It is an RPC integrity error of the provider, not a fault of the oracle output.
+var errHeaderIntegrity = errors.New("inconsistent L2 block header")
+
+// anchorBlock is the latest block whose output root was computed by an L2 provider, later blocks are expected to build on it.
+type anchorBlock struct {
+	number uint64
+	hash   common.Hash
+}
+
+// verifyHeader checks that the block header served by the L2 provider is the canonical block at its height:
+// it is served again when fetched by hash, it links to its parent and the previously computed block of the provider is still part of the chain.
+func (v *proofVerifier) verifyHeader(provider *l2Provider, header *types.Header) error {
+	blockHash := header.Hash()
+	headerByHash, err := provider.client.GetBlockHeaderByHash(v.ctx, blockHash)
+	if err != nil {
+		v.logger.Errorf("Failed to fetch block header by hash: %s from provider %s, error: %v.", blockHash, provider.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return err
+	}
+	if headerByHash.Hash() != blockHash || headerByHash.Number.Cmp(header.Number) != 0 {
+		return fmt.Errorf("%w: block %d with hash %s is served as block %d with hash %s when fetched by hash", errHeaderIntegrity, header.Number, blockHash, headerByHash.Number, headerByHash.Hash())
+	}
+
+	blockNumber := header.Number.Uint64()
+	if blockNumber == 0 {
+		return nil
+	}
+
+	parentHeader, err := provider.client.GetBlockHeaderByNumber(v.ctx, encoding.MustConvertUint64ToBigInt(blockNumber-1))
+	if err != nil {
+		v.logger.Errorf("Failed to fetch block header by number: %d from provider %s, error: %v.", blockNumber-1, provider.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return err
+	}
+	if parentHeader.Hash() != header.ParentHash {
+		return fmt.Errorf("%w: parent hash %s of block %d does not match block %d with hash %s", errHeaderIntegrity, header.ParentHash, blockNumber, blockNumber-1, parentHeader.Hash())
+	}
+
+	anchor := provider.getAnchor()
+	if anchor == nil || anchor.number >= blockNumber {
+		return nil
+	}
+	anchorHeader, err := provider.client.GetBlockHeaderByNumber(v.ctx, encoding.MustConvertUint64ToBigInt(anchor.number))
+	if err != nil {
+		v.logger.Errorf("Failed to fetch block header by number: %d from provider %s, error: %v.", anchor.number, provider.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return err
+	}
+	if anchorHeader.Hash() != anchor.hash {
+		// The mismatch is reported once, the later blocks are then expected to build on the block currently served at the anchor height
+		provider.reanchor(anchor, anchorHeader)
+		return fmt.Errorf("%w: previously computed block %d with hash %s is now served with hash %s", errHeaderIntegrity, anchor.number, anchor.hash, anchorHeader.Hash())
+	}
+
+	return nil
+}
+
+// getAnchor returns the latest block whose output root was computed by the provider, or nil when none.
+func (p *l2Provider) getAnchor() *anchorBlock {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.anchor
+}
+
+// setAnchor records the block whose output root was computed by the provider, when later than the current anchor.
+func (p *l2Provider) setAnchor(header *types.Header) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.anchor == nil || header.Number.Uint64() > p.anchor.number {
+		p.anchor = &anchorBlock{number: header.Number.Uint64(), hash: header.Hash()}
+	}
+}
+
+// reanchor replaces the given anchor with the header currently served at its height, unless the anchor was already moved, e.g. by another catch-up worker.
+func (p *l2Provider) reanchor(previous *anchorBlock, header *types.Header) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.anchor == previous {
+		p.anchor = &anchorBlock{number: header.Number.Uint64(), hash: header.Hash()}
+	}
+}
@@ -0,0 +1,139 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockL2TimestampComputer struct {
+	mockOracleAccessor
+}
+
+func (o *mockL2TimestampComputer) ComputeL2Timestamp(l2BlockNumber uint64) (uint64, error) {
+	called := o.MethodCalled("ComputeL2Timestamp", l2BlockNumber)
+	return called.Get(0).(uint64), called.Error(1)
+}
+
+func TestProofVerifier_VerifyHeader(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	anchor := newMockL2Header(500, common.Hash{}, randHash())
+	parent := newMockL2Header(l2BlockNumber-1, common.Hash{}, randHash())
+	header := newMockL2Header(l2BlockNumber, parent.Hash(), randHash())
+
+	tests := []struct {
+		name         string
+		headerByHash *types.Header
+		parent       *types.Header
+		anchor       *anchorBlock
+		expectedErr  bool
+	}{
+		{
+			name:         "should return nil when the header is consistent",
+			headerByHash: header,
+			parent:       parent,
+			anchor:       &anchorBlock{number: anchor.Number.Uint64(), hash: anchor.Hash()},
+		},
+		{
+			name:         "should return error when another header is served by hash",
+			headerByHash: newMockL2Header(l2BlockNumber, parent.Hash(), randHash()),
+			parent:       parent,
+			expectedErr:  true,
+		},
+		{
+			name:         "should return error when the header does not link to its parent",
+			headerByHash: header,
+			parent:       newMockL2Header(l2BlockNumber-1, common.Hash{}, randHash()),
+			expectedErr:  true,
+		},
+		{
+			name:         "should return error when the previously computed block is no longer part of the chain",
+			headerByHash: header,
+			parent:       parent,
+			anchor:       &anchorBlock{number: anchor.Number.Uint64(), hash: randHash()},
+			expectedErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := new(mockL2ChainAPIClient)
+			client.On("GetBlockHeaderByHash", mock.Anything, header.Hash()).Return(test.headerByHash, nil)
+			client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber-1))).Return(test.parent, nil)
+			client.On("GetBlockHeaderByNumber", mock.Anything, anchor.Number).Return(anchor, nil)
+
+			logger, _ := log.NewDefaultProductionLogger()
+			verifier := &proofVerifier{
+				ctx:     context.Background(),
+				logger:  logger,
+				metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+			}
+
+			err := verifier.verifyHeader(&l2Provider{name: "a", client: client, anchor: test.anchor}, header)
+			if test.expectedErr {
+				require.ErrorIs(t, err, errHeaderIntegrity)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestL2Provider_SetAnchor(t *testing.T) {
+	provider := &l2Provider{name: "a"}
+	later := newMockL2Header(2000, common.Hash{}, randHash())
+	provider.setAnchor(later)
+	require.Equal(t, later.Hash(), provider.getAnchor().hash)
+
+	// Outputs verified out of order by the catch-up workers do not move the anchor back
+	provider.setAnchor(newMockL2Header(1000, common.Hash{}, randHash()))
+	require.Equal(t, uint64(2000), provider.getAnchor().number)
+}
+
+func TestProofVerifier_VerifyHeader_Reanchor(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	anchor := newMockL2Header(500, common.Hash{}, randHash())
+	parent := newMockL2Header(l2BlockNumber-1, common.Hash{}, randHash())
+	header := newMockL2Header(l2BlockNumber, parent.Hash(), randHash())
+
+	client := new(mockL2ChainAPIClient)
+	client.On("GetBlockHeaderByHash", mock.Anything, header.Hash()).Return(header, nil)
+	client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber-1))).Return(parent, nil)
+	client.On("GetBlockHeaderByNumber", mock.Anything, anchor.Number).Return(anchor, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	verifier := &proofVerifier{
+		ctx:     context.Background(),
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+	}
+	provider := &l2Provider{name: "a", client: client, anchor: &anchorBlock{number: anchor.Number.Uint64(), hash: randHash()}}
+
+	// The mismatch is reported once, the provider is then anchored to the block it currently serves
+	require.ErrorIs(t, verifier.verifyHeader(provider, header), errHeaderIntegrity)
+	require.Equal(t, anchor.Hash(), provider.getAnchor().hash)
+	require.NoError(t, verifier.verifyHeader(provider, header))
+}
+
+func TestL2Provider_Reanchor(t *testing.T) {
+	provider := &l2Provider{name: "a"}
+	provider.setAnchor(newMockL2Header(1000, common.Hash{}, randHash()))
+	previous := provider.getAnchor()
+
+	// The anchor moved by another worker is kept
+	later := newMockL2Header(2000, common.Hash{}, randHash())
+	provider.setAnchor(later)
+	provider.reanchor(previous, newMockL2Header(1000, common.Hash{}, randHash()))
+	require.Equal(t, later.Hash(), provider.getAnchor().hash)
+
+	served := newMockL2Header(2000, common.Hash{}, randHash())
+	provider.reanchor(provider.getAnchor(), served)
+	require.Equal(t, served.Hash(), provider.getAnchor().hash)
+}
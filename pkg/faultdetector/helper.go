@@ -8,19 +8,80 @@ import (
 	"github.com/LiskHQ/op-fault-detector/pkg/chain"
 	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
 	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 type ChainAPIClient interface {
 	GetLatestBlockHeader(ctx context.Context) (*types.Header, error)
 }
 
+// L2ChainAPIClient is implemented by the L2 API clients used to compute the output roots.
+type L2ChainAPIClient interface {
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+	GetBlockHeaderByNumber(ctx context.Context, blockNumber *big.Int) (*types.Header, error)
+	GetBlockHeaderByHash(ctx context.Context, blockHash common.Hash) (*types.Header, error)
+	GetProof(ctx context.Context, blockNumber *big.Int, address common.Address) (*chain.ProofResponse, error)
+}
+
 type OracleAccessor interface {
 	GetNextOutputIndex() (*big.Int, error)
 	GetL2Output(index *big.Int) (chain.L2Output, error)
 	FinalizationPeriodSeconds() (*big.Int, error)
 }
 
+// L1BlockPinner is implemented by the oracle accessors whose reads can be pinned to the L1 block at the configured read depth.
+type L1BlockPinner interface {
+	PinL1Block(ctx context.Context) (*types.Header, error)
+}
+
+// SubmissionIntervalReader is implemented by the oracle accessors whose outputs are proposed at a fixed interval.
+type SubmissionIntervalReader interface {
+	SubmissionIntervalSeconds() (*big.Int, error)
+}
+
+// OutputProposalReader is implemented by the oracle accessors that can look up the L1 transaction which proposed an output and the address allowed to propose the outputs.
+type OutputProposalReader interface {
+	GetOutputProposal(ctx context.Context, index uint64, outputRoot string, l1Timestamp uint64) (*chain.OutputProposal, error)
+	Proposer() (common.Address, error)
+}
+
+// L2TimestampComputer is implemented by the oracle accessors that compute the expected timestamp of an L2 block.
+type L2TimestampComputer interface {
+	ComputeL2Timestamp(l2BlockNumber uint64) (uint64, error)
+}
+
+// OutputScheduleReader is implemented by the oracle accessors whose outputs are proposed for L2 blocks at a fixed interval from a starting block.
+type OutputScheduleReader interface {
+	GetOutputSchedule() (chain.OutputSchedule, error)
+}
+
+// OracleParametersReader is implemented by the oracle accessors whose proxy implementation and parameters can change after an upgrade.
+type OracleParametersReader interface {
+	GetOracleParameters(ctx context.Context) (chain.OracleParameters, error)
+}
+
+// OutputSubscriber is implemented by the oracle accessors that notify about the newly proposed outputs.
+type OutputSubscriber interface {
+	SubscribeOutputProposed(ctx context.Context, sink chan<- chain.L2Output) (event.Subscription, error)
+}
+
+// OutputsDeletedSubscriber is implemented by the oracle accessors that notify about the outputs deleted from the oracle.
+type OutputsDeletedSubscriber interface {
+	SubscribeOutputsDeleted(ctx context.Context, sink chan<- chain.OutputsDeleted) (event.Subscription, error)
+}
+
+// OutputsDeletedFilterer is implemented by the oracle accessors that can query the outputs deleted from the oracle within an L1 block range.
+type OutputsDeletedFilterer interface {
+	GetOutputsDeleted(ctx context.Context, fromBlock uint64, toBlock uint64) ([]chain.OutputsDeleted, error)
+}
+
+// DisputeGameAccessor is implemented by the oracle accessors whose outputs are the root claims of dispute games.
+type DisputeGameAccessor interface {
+	GetDisputeGame(index *big.Int) (chain.DisputeGame, error)
+}
+
 // FindFirstUnfinalizedOutputIndex finds and returns the first L2 output index that has not yet passed the fault proof window.
 func FindFirstUnfinalizedOutputIndex(ctx context.Context, logger log.Logger, fpw uint64, oracleAccessor OracleAccessor, l2RpcApi ChainAPIClient) (uint64, error) {
 	latestBlockHeader, err := l2RpcApi.GetLatestBlockHeader(ctx)
@@ -0,0 +1,161 @@
+package faultdetector
+
+import (
+	"fmt"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+)
+
+// outputInvariant is a structural property of the outputs published to the oracle, checked alongside the output root verification.
+type outputInvariant interface {
+	// name identifies the invariant in the logs, notifications and metrics.
+	name() string
+	// check returns a description of the violation, or an empty string when the output satisfies the invariant.
+	// The previous output is nil for the first output of the oracle, the block timestamp is the timestamp of the L2 block of the output served by the L2 providers.
+	check(output chain.L2Output, previous *chain.L2Output, blockTimestamp uint64) (string, error)
+}
+
+// invariantViolation is an output that does not satisfy an invariant.
+type invariantViolation struct {
+	invariant   string
+	outputIndex uint64
+	l1Timestamp uint64
+	description string
+}
+
+// reportedInvariantViolation identifies a reported violation, an output proposed again after a deletion is reported again.
+type reportedInvariantViolation struct {
+	invariant   string
+	outputIndex uint64
+	l1Timestamp uint64
+}
+
+// l2BlockNumberInvariant checks that the L2 block number of every output is `startingBlockNumber + (index + 1) * SUBMISSION_INTERVAL`,
+// i.e. every output is proposed one submission interval after the previous output, the first one after the starting block.
+type l2BlockNumberInvariant struct {
+	reader OutputScheduleReader
+}
+
+func (i *l2BlockNumberInvariant) name() string {
+	return "l2_block_number"
+}
+
+func (i *l2BlockNumberInvariant) check(output chain.L2Output, _ *chain.L2Output, _ uint64) (string, error) {
+	schedule, err := i.reader.GetOutputSchedule()
+	if err != nil {
+		return "", err
+	}
+
+	expectedL2BlockNumber := schedule.StartingBlockNumber + (output.L2OutputIndex+1)*schedule.SubmissionInterval
+	if output.L2BlockNumber != expectedL2BlockNumber {
+		return fmt.Sprintf("L2 block number %d does not match the expected L2 block number %d", output.L2BlockNumber, expectedL2BlockNumber), nil
+	}
+	return "", nil
+}
+
+// l1TimestampInvariant checks that the outputs are proposed in order, i.e. the L1 timestamps of the outputs are monotonic.
+type l1TimestampInvariant struct{}
+
+func (i *l1TimestampInvariant) name() string {
+	return "l1_timestamp"
+}
+
+func (i *l1TimestampInvariant) check(output chain.L2Output, previous *chain.L2Output, _ uint64) (string, error) {
+	if previous != nil && output.L1Timestamp < previous.L1Timestamp {
+		return fmt.Sprintf("L1 timestamp %d is before the L1 timestamp %d of the previous output", output.L1Timestamp, previous.L1Timestamp), nil
+	}
+	return "", nil
+}
+
+// l2TimestampInvariant checks that the L2 block of every output is served at the timestamp computed by the oracle for its height, and that it precedes the proposal of the output.
+type l2TimestampInvariant struct {
+	computer L2TimestampComputer
+}
+
+func (i *l2TimestampInvariant) name() string {
+	return "l2_timestamp"
+}
+
+func (i *l2TimestampInvariant) check(output chain.L2Output, _ *chain.L2Output, blockTimestamp uint64) (string, error) {
+	l2Timestamp, err := i.computer.ComputeL2Timestamp(output.L2BlockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	if blockTimestamp != l2Timestamp {
+		return fmt.Sprintf("timestamp %d of the L2 block %d does not match the L2 timestamp %d computed by the oracle", blockTimestamp, output.L2BlockNumber, l2Timestamp), nil
+	}
+	if l2Timestamp >= output.L1Timestamp {
+		return fmt.Sprintf("L2 timestamp %d of the L2 block %d is not before the L1 timestamp %d of the proposal", l2Timestamp, output.L2BlockNumber, output.L1Timestamp), nil
+	}
+	return "", nil
+}
+
+// newOutputInvariants returns the invariants supported by the oracle accessor.
+func newOutputInvariants(oracleAccessor OracleAccessor) []outputInvariant {
+	invariants := []outputInvariant{&l1TimestampInvariant{}}
+	if reader, ok := oracleAccessor.(OutputScheduleReader); ok {
+		invariants = append(invariants, &l2BlockNumberInvariant{reader: reader})
+	}
+	if computer, ok := oracleAccessor.(L2TimestampComputer); ok {
+		invariants = append(invariants, &l2TimestampInvariant{computer: computer})
+	}
+	return invariants
+}
+
+// checkOutputInvariants checks the output, whose L2 block is served at the given timestamp, against every configured invariant and returns the violations.
+func (fd *FaultDetector) checkOutputInvariants(output chain.L2Output, blockTimestamp uint64) ([]*invariantViolation, error) {
+	if len(fd.outputInvariants) == 0 {
+		return nil, nil
+	}
+
+	var previous *chain.L2Output
+	if output.L2OutputIndex > 0 {
+		previousOutput, err := fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(output.L2OutputIndex - 1))
+		if err != nil {
+			fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", output.L2OutputIndex-1, err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return nil, err
+		}
+		previous = &previousOutput
+	}
+
+	var violations []*invariantViolation
+	for _, invariant := range fd.outputInvariants {
+		description, err := invariant.check(output, previous, blockTimestamp)
+		if err != nil {
+			fd.logger.Errorf("Failed to check %s invariant of output with index: %d, error: %v.", invariant.name(), output.L2OutputIndex, err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return nil, err
+		}
+		if description != "" {
+			violations = append(violations, &invariantViolation{
+				invariant:   invariant.name(),
+				outputIndex: output.L2OutputIndex,
+				l1Timestamp: output.L1Timestamp,
+				description: description,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// reportInvariantViolations alerts about the invariant violations of the verified output.
+// Every violation is only reported once, even though a diverged output is verified again until it is resolved.
+func (fd *FaultDetector) reportInvariantViolations(verification *outputVerification) {
+	for _, violation := range verification.invariantViolations {
+		reported := reportedInvariantViolation{invariant: violation.invariant, outputIndex: violation.outputIndex, l1Timestamp: violation.l1Timestamp}
+		if fd.reportedViolations[reported] {
+			continue
+		}
+		if fd.reportedViolations == nil {
+			fd.reportedViolations = make(map[reportedInvariantViolation]bool)
+		}
+		fd.reportedViolations[reported] = true
+
+		fd.metrics.oracleInvariantViolations.WithLabelValues(violation.invariant).Inc()
+		fd.logger.Errorf("Output with index %d violates the %s invariant: %s.", violation.outputIndex, violation.invariant, violation.description)
+		fd.notify(fmt.Sprintf("*Oracle invariant violated*, output does not satisfy the %s invariant:\nOutputIndex: %d\nL2BlockNumber: %d\nL1Timestamp: %d\nViolation: %s", violation.invariant, violation.outputIndex, verification.l2BlockNumber, violation.l1Timestamp, violation.description))
+	}
+}
@@ -0,0 +1,150 @@
+package faultdetector
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutputScheduleReader struct {
+	mockL2TimestampComputer
+}
+
+func (o *mockOutputScheduleReader) GetOutputSchedule() (chain.OutputSchedule, error) {
+	called := o.MethodCalled("GetOutputSchedule")
+	return called.Get(0).(chain.OutputSchedule), called.Error(1)
+}
+
+func TestNewOutputInvariants(t *testing.T) {
+	require.Equal(t, []string{"l1_timestamp"}, outputInvariantNames(newOutputInvariants(new(mockOracleAccessor))))
+	require.Equal(t, []string{"l1_timestamp", "l2_block_number", "l2_timestamp"}, outputInvariantNames(newOutputInvariants(new(mockOutputScheduleReader))))
+}
+
+func outputInvariantNames(invariants []outputInvariant) []string {
+	names := make([]string, 0, len(invariants))
+	for _, invariant := range invariants {
+		names = append(names, invariant.name())
+	}
+	return names
+}
+
+func TestCheckOutputInvariants(t *testing.T) {
+	previous := chain.L2Output{L2OutputIndex: 4, L2BlockNumber: 1400, L1Timestamp: 20000}
+
+	tests := []struct {
+		name               string
+		output             chain.L2Output
+		l2Timestamp        uint64
+		blockTimestamp     uint64
+		expectedInvariants []string
+	}{
+		{
+			name:               "should return no violation when the output satisfies every invariant",
+			output:             chain.L2Output{L2OutputIndex: 5, L2BlockNumber: 1600, L1Timestamp: 21000},
+			l2Timestamp:        20800,
+			blockTimestamp:     20800,
+			expectedInvariants: nil,
+		},
+		{
+			name:               "should return violation when the L2 block number is off the schedule",
+			output:             chain.L2Output{L2OutputIndex: 5, L2BlockNumber: 1601, L1Timestamp: 21000},
+			l2Timestamp:        20802,
+			blockTimestamp:     20802,
+			expectedInvariants: []string{"l2_block_number"},
+		},
+		{
+			name:               "should return violation when the L1 timestamp is before the previous output",
+			output:             chain.L2Output{L2OutputIndex: 5, L2BlockNumber: 1600, L1Timestamp: 19000},
+			l2Timestamp:        18800,
+			blockTimestamp:     18800,
+			expectedInvariants: []string{"l1_timestamp"},
+		},
+		{
+			name:               "should return violation when the L2 block is not before the proposal",
+			output:             chain.L2Output{L2OutputIndex: 5, L2BlockNumber: 1600, L1Timestamp: 21000},
+			l2Timestamp:        21000,
+			blockTimestamp:     21000,
+			expectedInvariants: []string{"l2_timestamp"},
+		},
+		{
+			name:               "should return violation when the L2 block is not served at the timestamp computed by the oracle",
+			output:             chain.L2Output{L2OutputIndex: 5, L2BlockNumber: 1600, L1Timestamp: 21000},
+			l2Timestamp:        20800,
+			blockTimestamp:     20802,
+			expectedInvariants: []string{"l2_timestamp"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oracle := new(mockOutputScheduleReader)
+			oracle.On("GetL2Output", big.NewInt(4)).Return(previous, nil)
+			oracle.On("GetOutputSchedule").Return(chain.OutputSchedule{StartingBlockNumber: 400, SubmissionInterval: 200}, nil)
+			oracle.On("ComputeL2Timestamp", test.output.L2BlockNumber).Return(test.l2Timestamp, nil)
+
+			logger, _ := log.NewDefaultProductionLogger()
+			fd := &FaultDetector{
+				logger:                 logger,
+				metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				oracleContractAccessor: oracle,
+				outputInvariants:       newOutputInvariants(oracle),
+			}
+
+			violations, err := fd.checkOutputInvariants(test.output, test.blockTimestamp)
+			require.NoError(t, err)
+			invariants := []string(nil)
+			for _, violation := range violations {
+				invariants = append(invariants, violation.invariant)
+			}
+			require.Equal(t, test.expectedInvariants, invariants)
+		})
+	}
+}
+
+func TestCheckOutputInvariants_FirstOutput(t *testing.T) {
+	oracle := new(mockOracleAccessor)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		oracleContractAccessor: oracle,
+		outputInvariants:       newOutputInvariants(oracle),
+	}
+
+	// The first output has no previous output to compare with
+	violations, err := fd.checkOutputInvariants(chain.L2Output{L2OutputIndex: 0, L2BlockNumber: 600, L1Timestamp: 1000}, 800)
+	require.NoError(t, err)
+	require.Empty(t, violations)
+	oracle.AssertNotCalled(t, "GetL2Output")
+}
+
+func TestReportInvariantViolations(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+	}
+	verification := &outputVerification{
+		outputIndex:   5,
+		l2BlockNumber: 1601,
+		invariantViolations: []*invariantViolation{
+			{invariant: "l2_block_number", outputIndex: 5, l1Timestamp: 21000, description: "L2 block number 1601 does not match the expected L2 block number 1600"},
+		},
+	}
+
+	fd.reportInvariantViolations(verification)
+	// A diverged output verified again is not reported twice
+	fd.reportInvariantViolations(verification)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.oracleInvariantViolations.WithLabelValues("l2_block_number")))
+
+	// The same output proposed again after a deletion is reported again
+	verification.invariantViolations[0].l1Timestamp = 22000
+	fd.reportInvariantViolations(verification)
+	require.Equal(t, float64(2), testutil.ToFloat64(fd.metrics.oracleInvariantViolations.WithLabelValues("l2_block_number")))
+}
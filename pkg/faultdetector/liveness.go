@@ -0,0 +1,54 @@
+package faultdetector
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+)
+
+// proposalDeadline returns the time by which the output following the latest proposal is considered overdue.
+func (fd *FaultDetector) proposalDeadline() time.Time {
+	return fd.lastProposalTime.Add(time.Duration(float64(fd.submissionInterval) * fd.livenessIntervalMultiplier))
+}
+
+// checkProposerLiveness alerts when no new output is proposed within the configured multiple of the oracle submission interval since the latest proposal, and again when the proposals resume.
+// It is only performed when enabled and the oracle accessor exposes the submission interval.
+func (fd *FaultDetector) checkProposerLiveness(nextOutputIndex uint64) error {
+	if fd.livenessIntervalMultiplier == 0 || fd.submissionInterval == 0 || nextOutputIndex == 0 {
+		return nil
+	}
+
+	latestOutputIndex := nextOutputIndex - 1
+	if fd.lastProposalTime.IsZero() || latestOutputIndex != fd.lastProposalIndex {
+		l2OutputData, err := fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(latestOutputIndex))
+		if err != nil {
+			fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", latestOutputIndex, err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return err
+		}
+
+		fd.lastProposalIndex = latestOutputIndex
+		fd.lastProposalTime = time.Unix(int64(l2OutputData.L1Timestamp), 0)
+		fd.logger.Debugf("Latest output with index %d was proposed at %s, next proposal is due at %s.", latestOutputIndex, fd.lastProposalTime, fd.lastProposalTime.Add(fd.submissionInterval))
+	}
+
+	deadline := fd.proposalDeadline()
+	stalled := time.Now().After(deadline)
+	if stalled == fd.proposerStalled {
+		return nil
+	}
+	fd.proposerStalled = stalled
+
+	if stalled {
+		fd.metrics.proposerStalled.Set(1)
+		fd.logger.Warningf("No output proposed since output with index %d at %s, expected every %s.", latestOutputIndex, fd.lastProposalTime, fd.submissionInterval)
+		fd.notify(fmt.Sprintf("*Proposer stalled*, no output proposed within the expected interval:\nLatestOutputIndex: %d\nLastProposalTime: %s\nSubmissionInterval: %s\nOverdueSince: %s", latestOutputIndex, fd.lastProposalTime, fd.submissionInterval, deadline))
+		return nil
+	}
+
+	fd.metrics.proposerStalled.Set(0)
+	fd.logger.Infof("Proposals resumed with output with index %d at %s.", latestOutputIndex, fd.lastProposalTime)
+	fd.notify(fmt.Sprintf("*Proposals resumed*, new output proposed after the proposer stalled:\nOutputIndex: %d\nProposalTime: %s", latestOutputIndex, fd.lastProposalTime))
+	return nil
+}
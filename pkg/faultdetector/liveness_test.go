@@ -0,0 +1,66 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckProposerLiveness(t *testing.T) {
+	const submissionInterval = time.Hour
+	now := time.Now()
+
+	// Output 9 is overdue, output 10 is proposed within the expected interval
+	oracle := new(mockOracleAccessor)
+	oracle.On("GetL2Output", big.NewInt(9)).Return(chain.L2Output{L2OutputIndex: 9, L1Timestamp: uint64(now.Add(-3 * submissionInterval).Unix())}, nil)
+	oracle.On("GetL2Output", big.NewInt(10)).Return(chain.L2Output{L2OutputIndex: 10, L1Timestamp: uint64(now.Add(-submissionInterval / 2).Unix())}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                        context.Background(),
+		logger:                     logger,
+		metrics:                    NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		oracleContractAccessor:     oracle,
+		submissionInterval:         submissionInterval,
+		livenessIntervalMultiplier: 2,
+	}
+
+	require.NoError(t, fd.checkProposerLiveness(10))
+	require.True(t, fd.proposerStalled)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.proposerStalled))
+
+	// The latest output is only fetched again once a new output is proposed
+	require.NoError(t, fd.checkProposerLiveness(10))
+	oracle.AssertNumberOfCalls(t, "GetL2Output", 1)
+
+	require.NoError(t, fd.checkProposerLiveness(11))
+	require.False(t, fd.proposerStalled)
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.proposerStalled))
+	oracle.AssertNumberOfCalls(t, "GetL2Output", 2)
+}
+
+func TestCheckProposerLiveness_Disabled(t *testing.T) {
+	oracle := new(mockOracleAccessor)
+	oracle.On("GetL2Output", mock.Anything).Return(chain.L2Output{}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		oracleContractAccessor: oracle,
+		submissionInterval:     time.Hour,
+	}
+
+	require.NoError(t, fd.checkProposerLiveness(10))
+	require.False(t, fd.proposerStalled)
+	oracle.AssertNotCalled(t, "GetL2Output", mock.Anything)
+}
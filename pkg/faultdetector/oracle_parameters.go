@@ -0,0 +1,99 @@
+package faultdetector
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+)
+
+// oracleParameterChange holds the old and new value of a changed oracle parameter.
+type oracleParameterChange struct {
+	parameter string
+	oldValue  string
+	newValue  string
+}
+
+// diffOracleParameters returns the parameters whose value differs between the previous and current oracle parameters.
+func diffOracleParameters(previous chain.OracleParameters, current chain.OracleParameters) []oracleParameterChange {
+	var changes []oracleParameterChange
+	if previous.Implementation != current.Implementation {
+		changes = append(changes, oracleParameterChange{"implementation", previous.Implementation.Hex(), current.Implementation.Hex()})
+	}
+	if previous.FinalizationPeriodSeconds != current.FinalizationPeriodSeconds {
+		changes = append(changes, oracleParameterChange{"finalization_period_seconds", fmt.Sprint(previous.FinalizationPeriodSeconds), fmt.Sprint(current.FinalizationPeriodSeconds)})
+	}
+	if previous.Challenger != current.Challenger {
+		changes = append(changes, oracleParameterChange{"challenger", previous.Challenger.Hex(), current.Challenger.Hex()})
+	}
+	if previous.Proposer != current.Proposer {
+		changes = append(changes, oracleParameterChange{"proposer", previous.Proposer.Hex(), current.Proposer.Hex()})
+	}
+	return changes
+}
+
+// checkOracleParameters reads the oracle proxy implementation and parameters, at most once every configured interval.
+// Every change is notified with its old and new value, and the fault proof window is refreshed along with the finalization time of the diverged outputs when the finalization period changes.
+func (fd *FaultDetector) checkOracleParameters() {
+	if fd.oracleParametersReader == nil || time.Since(fd.lastOracleParametersCheck) < fd.oracleParametersInterval {
+		return
+	}
+	fd.lastOracleParametersCheck = time.Now()
+
+	parameters, err := fd.oracleParametersReader.GetOracleParameters(fd.ctx)
+	if err != nil {
+		fd.logger.Errorf("Failed to query oracle parameters, error: %v.", err)
+		fd.metrics.apiConnectionFailure.Inc()
+		return
+	}
+
+	if parameters.FinalizationPeriodSeconds != fd.faultProofWindow {
+		fd.logger.Infof("Fault proof window is updated from %d to %d seconds.", fd.faultProofWindow, parameters.FinalizationPeriodSeconds)
+		fd.refreshFinalizationTimes(fd.faultProofWindow, parameters.FinalizationPeriodSeconds)
+		fd.faultProofWindow = parameters.FinalizationPeriodSeconds
+	}
+
+	if fd.oracleParameters == nil {
+		fd.logger.Infof("Oracle implementation is %s with finalization period of %d seconds, challenger %s and proposer %s.", parameters.Implementation, parameters.FinalizationPeriodSeconds, parameters.Challenger, parameters.Proposer)
+		fd.oracleParameters = &parameters
+		return
+	}
+
+	for _, change := range diffOracleParameters(*fd.oracleParameters, parameters) {
+		fd.metrics.oracleParameterChanges.WithLabelValues(change.parameter).Inc()
+		fd.logger.Warningf("Oracle parameter %s changed from %s to %s.", change.parameter, change.oldValue, change.newValue)
+		fd.notify(fmt.Sprintf("*Oracle parameter changed*, the oracle was upgraded or reconfigured:\nParameter: %s\nOldValue: %s\nNewValue: %s", change.parameter, change.oldValue, change.newValue))
+	}
+	fd.oracleParameters = &parameters
+}
+
+// finalizationTime returns the time the output proposed at the given L1 timestamp is finalized, as computed by the oracle.
+func finalizationTime(l1Timestamp uint64, periodSeconds uint64) time.Time {
+	return time.Unix(int64(l1Timestamp+periodSeconds), 0)
+}
+
+// refreshFinalizationTime returns the finalization time of the output proposed at the given L1 timestamp with the new finalization period.
+// The outputs restored without their L1 timestamp are shifted by the change of the finalization period instead.
+func refreshFinalizationTime(l1Timestamp uint64, previousFinalizationTime time.Time, previousPeriodSeconds uint64, periodSeconds uint64) time.Time {
+	if l1Timestamp > 0 {
+		return finalizationTime(l1Timestamp, periodSeconds)
+	}
+	return previousFinalizationTime.Add(time.Duration(int64(periodSeconds)-int64(previousPeriodSeconds)) * time.Second)
+}
+
+// refreshFinalizationTimes recomputes the finalization time of the diverged outputs and of the unresolved faults with the new finalization period.
+func (fd *FaultDetector) refreshFinalizationTimes(previousPeriodSeconds uint64, periodSeconds uint64) {
+	fd.mutex.Lock()
+	for _, divergedOutput := range fd.divergedOutputs {
+		divergedOutput.FinalizationTime = refreshFinalizationTime(divergedOutput.L1Timestamp, divergedOutput.FinalizationTime, previousPeriodSeconds, periodSeconds)
+	}
+	fd.refreshDivergedLocked()
+	fd.mutex.Unlock()
+
+	fd.saveCheckpoint()
+	if fd.faultHistory != nil {
+		if err := fd.faultHistory.refreshFinalizationTimes(previousPeriodSeconds, periodSeconds); err != nil {
+			fd.logger.Errorf("Failed to save fault history with refreshed finalization times, error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package faultdetector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOracleParametersReader struct {
+	mockOracleAccessor
+}
+
+func (o *mockOracleParametersReader) GetOracleParameters(ctx context.Context) (chain.OracleParameters, error) {
+	called := o.MethodCalled("GetOracleParameters", ctx)
+	return called.Get(0).(chain.OracleParameters), called.Error(1)
+}
+
+func TestDiffOracleParameters(t *testing.T) {
+	previous := chain.OracleParameters{
+		Implementation:            common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		FinalizationPeriodSeconds: 604800,
+		Challenger:                common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Proposer:                  common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	}
+
+	require.Empty(t, diffOracleParameters(previous, previous))
+
+	current := previous
+	current.Implementation = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	current.FinalizationPeriodSeconds = 12
+	require.Equal(t, []oracleParameterChange{
+		{"implementation", previous.Implementation.Hex(), current.Implementation.Hex()},
+		{"finalization_period_seconds", "604800", "12"},
+	}, diffOracleParameters(previous, current))
+}
+
+func TestCheckOracleParameters(t *testing.T) {
+	parameters := chain.OracleParameters{
+		Implementation:            common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		FinalizationPeriodSeconds: 604800,
+		Challenger:                common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Proposer:                  common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	}
+	upgraded := parameters
+	upgraded.Implementation = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	upgraded.FinalizationPeriodSeconds = 12
+
+	oracle := new(mockOracleParametersReader)
+	oracle.On("GetOracleParameters", mock.Anything).Return(parameters, nil).Once()
+	oracle.On("GetOracleParameters", mock.Anything).Return(upgraded, nil).Once()
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                      context.Background(),
+		logger:                   logger,
+		metrics:                  NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		oracleContractAccessor:   oracle,
+		oracleParametersReader:   oracle,
+		oracleParametersInterval: time.Hour,
+		faultProofWindow:         604800,
+		mutex:                    new(sync.RWMutex),
+		faultHistory:             &faultHistory{},
+		continuePastFaults:       true,
+	}
+	// Output 5 is still within the finalization period, output 7 diverged before the L1 timestamp was kept in the checkpoint
+	l1Timestamp := uint64(time.Now().Add(-time.Hour).Unix())
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 5, L1Timestamp: l1Timestamp, FinalizationTime: time.Unix(int64(l1Timestamp+604800), 0)})
+	fd.addDivergedOutput(&DivergedOutput{OutputIndex: 7, FinalizationTime: time.Unix(int64(l1Timestamp+604800), 0)})
+	fd.recordFault(&outputVerification{outputIndex: 5, l1Timestamp: l1Timestamp, expectedOutputRoot: "0x01", calculatedOutputRoot: "0x02", finalizationTime: finalizationTime(l1Timestamp, 604800)})
+
+	fd.checkOracleParameters()
+	require.Equal(t, parameters, *fd.oracleParameters)
+	require.True(t, fd.IsFaultDetected())
+
+	// The parameters are not read again within the configured interval
+	fd.checkOracleParameters()
+	oracle.AssertNumberOfCalls(t, "GetOracleParameters", 1)
+
+	fd.lastOracleParametersCheck = time.Time{}
+	fd.checkOracleParameters()
+	require.Equal(t, upgraded, *fd.oracleParameters)
+	require.Equal(t, uint64(12), fd.faultProofWindow)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.oracleParameterChanges.WithLabelValues("implementation")))
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.oracleParameterChanges.WithLabelValues("finalization_period_seconds")))
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.oracleParameterChanges.WithLabelValues("proposer")))
+
+	// The diverged outputs are finalized with the shortened finalization period
+	divergedOutputs := fd.DivergedOutputs()
+	require.Equal(t, time.Unix(int64(l1Timestamp+12), 0), divergedOutputs[0].FinalizationTime)
+	require.Equal(t, time.Unix(int64(l1Timestamp+12), 0), divergedOutputs[1].FinalizationTime)
+	require.False(t, fd.IsFaultDetected())
+	require.Nil(t, fd.divergedOutput)
+	require.Equal(t, float64(0), testutil.ToFloat64(fd.metrics.stateMismatch))
+	require.Equal(t, time.Unix(int64(l1Timestamp+12), 0), fd.FaultHistory()[0].FinalizationTime)
+}
@@ -0,0 +1,55 @@
+package faultdetector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// errInvalidProof is returned when an L2 provider serves an account proof that does not match the state root of the block.
+// It is an RPC integrity error of the provider, not a fault of the oracle output.
+var errInvalidProof = errors.New("invalid account proof")
+
+// verifyAccountProof verifies the account proof served by an L2 provider against the given state root.
+// The storage root, nonce, balance and code hash of the proof response are only trusted when they match the proven account.
+func verifyAccountProof(stateRoot common.Hash, address common.Address, proof *chain.ProofResponse) error {
+	if proof.Address != address {
+		return fmt.Errorf("%w: proof of address %s received for address %s", errInvalidProof, proof.Address, address)
+	}
+
+	proofDB := memorydb.New()
+	for _, node := range proof.AccountProof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return err
+		}
+	}
+
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(address.Bytes()), proofDB)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidProof, err)
+	}
+	if len(value) == 0 {
+		return fmt.Errorf("%w: account %s does not exist in state root %s", errInvalidProof, address, stateRoot)
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return fmt.Errorf("%w: failed to decode account %s, %v", errInvalidProof, address, err)
+	}
+
+	if account.Root != proof.StorageHash {
+		return fmt.Errorf("%w: storage hash %s does not match proven storage root %s", errInvalidProof, proof.StorageHash, account.Root)
+	}
+	if account.Nonce != uint64(proof.Nonce) || proof.Balance == nil || account.Balance.Cmp(proof.Balance.ToInt()) != 0 || common.BytesToHash(account.CodeHash) != proof.CodeHash {
+		return fmt.Errorf("%w: account fields of %s do not match the proven account", errInvalidProof, address)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package faultdetector
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+)
+
+// proofNodes collects the trie nodes of a Merkle proof in order.
+type proofNodes []hexutil.Bytes
+
+func (n *proofNodes) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
+func (n *proofNodes) Delete(key []byte) error {
+	return errors.New("not supported")
+}
+
+// newMessagePasserState returns the state root of a state holding the message passer account with the given storage root, along with its account proof.
+func newMessagePasserState(storageRoot common.Hash) (common.Hash, *chain.ProofResponse) {
+	address := common.HexToAddress(chain.L2BedrockMessagePasserAddress)
+	account := types.StateAccount{Nonce: 1, Balance: big.NewInt(0), Root: storageRoot, CodeHash: crypto.Keccak256(nil)}
+	value, _ := rlp.EncodeToBytes(&account)
+
+	state := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	// Other accounts to prove through branch nodes
+	for i := byte(0); i < 16; i++ {
+		state.MustUpdate(crypto.Keccak256([]byte{i}), value)
+	}
+	state.MustUpdate(crypto.Keccak256(address.Bytes()), value)
+
+	var nodes proofNodes
+	_ = state.Prove(crypto.Keccak256(address.Bytes()), &nodes)
+
+	return state.Hash(), &chain.ProofResponse{
+		Address:      address,
+		AccountProof: nodes,
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     common.BytesToHash(account.CodeHash),
+		Nonce:        1,
+		StorageHash:  storageRoot,
+	}
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	address := common.HexToAddress(chain.L2BedrockMessagePasserAddress)
+	storageRoot := randHash()
+	stateRoot, proof := newMessagePasserState(storageRoot)
+
+	tests := []struct {
+		name        string
+		stateRoot   common.Hash
+		tamper      func(proof *chain.ProofResponse)
+		expectedErr bool
+	}{
+		{
+			name:      "should return nil when the proof matches the state root",
+			stateRoot: stateRoot,
+		},
+		{
+			name:        "should return error when the proof does not match the state root",
+			stateRoot:   randHash(),
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the storage hash does not match the proven account",
+			stateRoot:   stateRoot,
+			tamper:      func(proof *chain.ProofResponse) { proof.StorageHash = randHash() },
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the nonce does not match the proven account",
+			stateRoot:   stateRoot,
+			tamper:      func(proof *chain.ProofResponse) { proof.Nonce = 2 },
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the proof is of another address",
+			stateRoot:   stateRoot,
+			tamper:      func(proof *chain.ProofResponse) { proof.Address = common.Address{} },
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when a proof node is missing",
+			stateRoot:   stateRoot,
+			tamper:      func(proof *chain.ProofResponse) { proof.AccountProof = proof.AccountProof[:len(proof.AccountProof)-1] },
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tampered := *proof
+			if test.tamper != nil {
+				test.tamper(&tampered)
+			}
+
+			err := verifyAccountProof(test.stateRoot, address, &tampered)
+			if test.expectedErr {
+				require.ErrorIs(t, err, errInvalidProof)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package faultdetector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// isExpectedProposer returns true when the output was proposed by the oracle proposer or an allowlisted address.
+func (fd *FaultDetector) isExpectedProposer(verification *outputVerification) bool {
+	sender := verification.proposal.Sender
+	return sender == verification.expectedProposer || fd.proposerAllowlist[sender]
+}
+
+// expectedProposers returns the oracle proposer followed by the sorted allowlisted addresses.
+func (fd *FaultDetector) expectedProposers(verification *outputVerification) []string {
+	allowlist := make([]string, 0, len(fd.proposerAllowlist))
+	for address := range fd.proposerAllowlist {
+		if address != verification.expectedProposer {
+			allowlist = append(allowlist, address.Hex())
+		}
+	}
+	sort.Strings(allowlist)
+	return append([]string{verification.expectedProposer.Hex()}, allowlist...)
+}
+
+// checkProposer alerts when the verified output was proposed by an address other than the oracle proposer or the configured allowlist.
+// Every unexpected proposal is only reported once, even though a diverged output is verified again until it is resolved.
+func (fd *FaultDetector) checkProposer(verification *outputVerification) {
+	proposal := verification.proposal
+	if proposal == nil {
+		return
+	}
+
+	fd.logger.Debugf("Output with index %d was proposed by %s in L1 transaction %s.", verification.outputIndex, proposal.Sender, proposal.L1TxHash)
+	if fd.isExpectedProposer(verification) {
+		return
+	}
+
+	last := fd.lastUnexpectedProposal
+	if last != nil && last.L2OutputIndex == proposal.L2OutputIndex && last.L1TxHash == proposal.L1TxHash {
+		return
+	}
+	fd.lastUnexpectedProposal = proposal
+
+	expectedProposers := strings.Join(fd.expectedProposers(verification), ", ")
+	fd.metrics.unexpectedProposer.Inc()
+	fd.logger.Errorf("Output with index %d was proposed by unexpected address %s in L1 transaction %s, expected proposers: %s.", verification.outputIndex, proposal.Sender, proposal.L1TxHash, expectedProposers)
+	fd.notify(fmt.Sprintf("*Unexpected proposer*, output proposed by an unexpected address:\nOutputIndex: %d\nProposer: %s\nExpectedProposers: %s\nL1TxHash: %s\nL1BlockNumber: %d", verification.outputIndex, proposal.Sender, expectedProposers, proposal.L1TxHash, proposal.L1BlockNumber))
+}
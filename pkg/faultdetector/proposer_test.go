@@ -0,0 +1,113 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutputProposalReader struct {
+	mockOracleAccessor
+}
+
+func (o *mockOutputProposalReader) GetOutputProposal(ctx context.Context, index uint64, outputRoot string, l1Timestamp uint64) (*chain.OutputProposal, error) {
+	called := o.MethodCalled("GetOutputProposal", ctx, index, outputRoot, l1Timestamp)
+	return called.Get(0).(*chain.OutputProposal), called.Error(1)
+}
+
+func (o *mockOutputProposalReader) Proposer() (common.Address, error) {
+	called := o.MethodCalled("Proposer")
+	return called.Get(0).(common.Address), called.Error(1)
+}
+
+func TestCheckProposer(t *testing.T) {
+	proposer := common.HexToAddress("0x473300df21D047806A082244b417f96b32f13A33")
+	allowlisted := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	unexpected := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	tests := []struct {
+		name                       string
+		sender                     common.Address
+		expectedUnexpectedProposer float64
+	}{
+		{
+			name:                       "should not alert when the output is proposed by the oracle proposer",
+			sender:                     proposer,
+			expectedUnexpectedProposer: 0,
+		},
+		{
+			name:                       "should not alert when the output is proposed by an allowlisted address",
+			sender:                     allowlisted,
+			expectedUnexpectedProposer: 0,
+		},
+		{
+			name:                       "should alert when the output is proposed by an unexpected address",
+			sender:                     unexpected,
+			expectedUnexpectedProposer: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			fd := &FaultDetector{
+				logger:            logger,
+				metrics:           NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				proposerAllowlist: map[common.Address]bool{allowlisted: true},
+			}
+			verification := &outputVerification{
+				outputIndex:      5,
+				proposal:         &chain.OutputProposal{L2OutputIndex: 5, Sender: test.sender, L1TxHash: randHash()},
+				expectedProposer: proposer,
+			}
+
+			fd.checkProposer(verification)
+			// A diverged output verified again is not reported twice
+			fd.checkProposer(verification)
+			require.Equal(t, test.expectedUnexpectedProposer, testutil.ToFloat64(fd.metrics.unexpectedProposer))
+		})
+	}
+}
+
+func TestVerifyOutput_Proposal(t *testing.T) {
+	proposer := common.HexToAddress("0x473300df21D047806A082244b417f96b32f13A33")
+	outputRoot := randHash().String()
+	proposal := &chain.OutputProposal{L2OutputIndex: 5, OutputRoot: outputRoot, L1BlockNumber: 10, L1TxHash: randHash(), Sender: proposer}
+
+	oracle := new(mockOutputProposalReader)
+	oracle.On("GetL2Output", big.NewInt(5)).Return(chain.L2Output{OutputRoot: outputRoot, L2BlockNumber: 600, L1Timestamp: 1500}, nil)
+	oracle.On("GetOutputProposal", mock.Anything, uint64(5), outputRoot, uint64(1500)).Return(proposal, nil)
+	oracle.On("Proposer").Return(proposer, nil)
+	verifier := new(mockOutputVerifier)
+	verifier.On("computeOutputRoot", uint64(5), uint64(600)).Return(&providerOutput{outputRoot: outputRoot}, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		oracleContractAccessor: oracle,
+		verifier:               verifier,
+		outputProposalReader:   oracle,
+	}
+
+	verification, err := fd.verifyOutput(5)
+	require.NoError(t, err)
+	require.Equal(t, proposal, verification.proposal)
+	require.Equal(t, proposer, verification.expectedProposer)
+
+	// The proposal is not looked up when the proposer check is disabled
+	fd.outputProposalReader = nil
+	verification, err = fd.verifyOutput(5)
+	require.NoError(t, err)
+	require.Nil(t, verification.proposal)
+	oracle.AssertNumberOfCalls(t, "GetOutputProposal", 1)
+}
@@ -0,0 +1,245 @@
+package faultdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// proofVerifier computes the output roots from the block headers and the message passer storage proofs served by the L2 providers.
+// An output root is only accepted when at least the quorum of providers agree on it.
+type proofVerifier struct {
+	ctx       context.Context
+	logger    log.Logger
+	metrics   *faultDetectorMetrics
+	providers []*l2Provider
+	quorum    uint
+}
+
+// l2Provider is an L2 API client used to compute the output roots, identified by its endpoint.
+type l2Provider struct {
+	name   string
+	client L2ChainAPIClient
+	anchor *anchorBlock
+	mutex  sync.Mutex
+}
+
+// providerOutput holds the output root computed by a single L2 provider.
+// The block header and the account proof the output root is computed from are only set by the proof-based verifier.
+type providerOutput struct {
+	outputRoot     string
+	blockTimestamp uint64
+	header         *types.Header
+	proof          *chain.ProofResponse
+	// endpoints are the names of the providers that computed the output root, i.e. their RPC endpoints.
+	endpoints []string
+}
+
+// nodeInconsistency holds the output roots computed by the L2 providers that did not agree with each other.
+type nodeInconsistency struct {
+	outputIndex   uint64
+	l2BlockNumber uint64
+	outputRoots   map[string]string
+}
+
+// String returns the output roots computed by every provider, sorted by provider name.
+func (n *nodeInconsistency) String() string {
+	names := make([]string, 0, len(n.outputRoots))
+	for name := range n.outputRoots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, n.outputRoots[name]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quorumError is returned when not enough L2 providers agreed on the output root, or when more than one output root reached the quorum.
+type quorumError struct {
+	quorum           uint
+	agreed           uint
+	providers        uint
+	conflictingRoots uint
+	inconsistency    *nodeInconsistency
+	errs             []error
+}
+
+func (e *quorumError) Error() string {
+	if e.conflictingRoots > 1 {
+		return fmt.Sprintf("quorum of %d reached by %d different output roots, computed by %d L2 providers", e.quorum, e.conflictingRoots, e.providers)
+	}
+	return fmt.Sprintf("quorum of %d not reached, %d of %d L2 providers agreed on the output root", e.quorum, e.agreed, e.providers)
+}
+
+// Unwrap returns the errors of the L2 providers that failed to compute the output root.
+func (e *quorumError) Unwrap() []error {
+	return e.errs
+}
+
+// computeOutputRoot computes the output root of the given L2 block with every L2 provider concurrently.
+// It returns the output root agreed on by at least the quorum of providers, along with the disagreement between the providers, if any.
+func (v *proofVerifier) computeOutputRoot(outputIndex uint64, l2BlockNumber uint64) (*providerOutput, *nodeInconsistency, error) {
+	outputs := make([]*providerOutput, len(v.providers))
+	errs := make([]error, len(v.providers))
+	var wg sync.WaitGroup
+	for i, provider := range v.providers {
+		wg.Add(1)
+		go func(i int, provider *l2Provider) {
+			defer wg.Done()
+			outputs[i], errs[i] = v.computeProviderOutputRoot(provider, l2BlockNumber)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	votes := make(map[string]uint)
+	outputRoots := make(map[string]string)
+	var agreedOutput *providerOutput
+	for i, output := range outputs {
+		if errs[i] != nil {
+			continue
+		}
+		outputRoots[v.providers[i].name] = output.outputRoot
+		votes[output.outputRoot]++
+		if agreedOutput == nil || votes[output.outputRoot] > votes[agreedOutput.outputRoot] {
+			agreedOutput = output
+		}
+	}
+
+	var inconsistency *nodeInconsistency
+	if len(votes) > 1 {
+		inconsistency = &nodeInconsistency{
+			outputIndex:   outputIndex,
+			l2BlockNumber: l2BlockNumber,
+			outputRoots:   outputRoots,
+		}
+	}
+
+	// The quorum is only meaningful when a single output root can reach it, e.g. not with a tie of a quorum lower than the majority
+	var rootsReachingQuorum uint
+	for _, count := range votes {
+		if count >= v.quorum {
+			rootsReachingQuorum++
+		}
+	}
+
+	if agreedOutput == nil || votes[agreedOutput.outputRoot] < v.quorum || rootsReachingQuorum > 1 {
+		// Report the error of the provider directly when there is only one, e.g. when the L2 node is behind
+		if len(v.providers) == 1 {
+			return nil, nil, errs[0]
+		}
+
+		err := &quorumError{
+			quorum:           v.quorum,
+			agreed:           votes[agreedOutput.getOutputRoot()],
+			providers:        uint(len(v.providers)),
+			conflictingRoots: rootsReachingQuorum,
+			inconsistency:    inconsistency,
+			errs:             errs,
+		}
+		v.logger.Errorf("Failed to compute output root for the block with height: %d, error: %v.", l2BlockNumber, err)
+		return nil, nil, err
+	}
+
+	// Keep track of every provider that agreed on the output root
+	for i, output := range outputs {
+		if errs[i] == nil && output.outputRoot == agreedOutput.outputRoot {
+			agreedOutput.endpoints = append(agreedOutput.endpoints, v.providers[i].name)
+		}
+	}
+
+	return agreedOutput, inconsistency, nil
+}
+
+// getOutputRoot returns the output root, or an empty string when no output is computed.
+func (o *providerOutput) getOutputRoot() string {
+	if o == nil {
+		return ""
+	}
+	return o.outputRoot
+}
+
+// computeProviderOutputRoot computes the output root of the given L2 block from the state of a single L2 provider.
+func (v *proofVerifier) computeProviderOutputRoot(provider *l2Provider, l2BlockNumber uint64) (*providerOutput, error) {
+	latestBlockNumber, err := provider.client.GetLatestBlockNumber(v.ctx)
+	if err != nil {
+		v.logger.Errorf("Failed to query L2 latest block number from provider %s: %d, error: %v", provider.name, latestBlockNumber, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return nil, err
+	}
+
+	if latestBlockNumber < l2BlockNumber {
+		v.logger.Infof("L2 node %s is behind, waiting for node to sync with the network...", provider.name)
+		return nil, errL2NodeBehind
+	}
+
+	outputBlockHeader, err := provider.client.GetBlockHeaderByNumber(v.ctx, encoding.MustConvertUint64ToBigInt(l2BlockNumber))
+	if err != nil {
+		v.logger.Errorf("Failed to fetch block header by number: %d from provider %s, error: %v.", l2BlockNumber, provider.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return nil, err
+	}
+
+	if err := v.verifyHeader(provider, outputBlockHeader); err != nil {
+		if errors.Is(err, errHeaderIntegrity) {
+			v.logger.Errorf("Failed to verify block header for the block with height: %d from provider %s, error: %v.", l2BlockNumber, provider.name, err)
+			v.metrics.rpcIntegrityFailure.Inc()
+		}
+		return nil, err
+	}
+
+	messagePasserAddress := common.HexToAddress(chain.L2BedrockMessagePasserAddress)
+	messagePasserProofResponse, err := provider.client.GetProof(v.ctx, encoding.MustConvertUint64ToBigInt(l2BlockNumber), messagePasserAddress)
+	if err != nil {
+		v.logger.Errorf("Failed to fetch message passer proof for the block with height: %d and address: %s from provider %s, error: %v.", l2BlockNumber, chain.L2BedrockMessagePasserAddress, provider.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return nil, err
+	}
+
+	// Only trust the storage hash once proven against the state root of the block
+	if err := verifyAccountProof(outputBlockHeader.Root, messagePasserAddress, messagePasserProofResponse); err != nil {
+		v.logger.Errorf("Failed to verify message passer proof for the block with height: %d from provider %s, error: %v.", l2BlockNumber, provider.name, err)
+		v.metrics.rpcIntegrityFailure.Inc()
+		return nil, err
+	}
+	provider.setAnchor(outputBlockHeader)
+
+	return &providerOutput{
+		outputRoot: encoding.ComputeL2OutputRoot(
+			outputBlockHeader.Root,
+			messagePasserProofResponse.StorageHash,
+			outputBlockHeader.Hash(),
+		),
+		blockTimestamp: outputBlockHeader.Time,
+		header:         outputBlockHeader,
+		proof:          messagePasserProofResponse,
+	}, nil
+}
+
+// reportNodeInconsistency alerts when the L2 providers computed different output roots, once per output index.
+// A node inconsistency is not a fault, the oracle output is only compared with the output root agreed on by the quorum.
+func (fd *FaultDetector) reportNodeInconsistency(inconsistency *nodeInconsistency) {
+	if inconsistency == nil {
+		return
+	}
+
+	fd.logger.Errorf("L2 providers computed different output roots for output index %d and block with height %d:\n%s", inconsistency.outputIndex, inconsistency.l2BlockNumber, inconsistency)
+	if fd.lastNodeInconsistency != nil && fd.lastNodeInconsistency.outputIndex == inconsistency.outputIndex {
+		return
+	}
+	fd.lastNodeInconsistency = inconsistency
+
+	fd.metrics.nodeInconsistency.Inc()
+	fd.notify(fmt.Sprintf("*Node inconsistency detected*, L2 providers computed different output roots:\noutputIndex: %d\nL2BlockNumber: %d\n%s", inconsistency.outputIndex, inconsistency.l2BlockNumber, inconsistency))
+}
@@ -0,0 +1,226 @@
+package faultdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockL2ChainAPIClient struct {
+	mock.Mock
+}
+
+func (m *mockL2ChainAPIClient) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	called := m.MethodCalled("GetLatestBlockNumber", ctx)
+	return called.Get(0).(uint64), called.Error(1)
+}
+
+func (m *mockL2ChainAPIClient) GetBlockHeaderByNumber(ctx context.Context, blockNumber *big.Int) (*types.Header, error) {
+	called := m.MethodCalled("GetBlockHeaderByNumber", ctx, blockNumber)
+	return called.Get(0).(*types.Header), called.Error(1)
+}
+
+func (m *mockL2ChainAPIClient) GetBlockHeaderByHash(ctx context.Context, blockHash common.Hash) (*types.Header, error) {
+	called := m.MethodCalled("GetBlockHeaderByHash", ctx, blockHash)
+	return called.Get(0).(*types.Header), called.Error(1)
+}
+
+func (m *mockL2ChainAPIClient) GetProof(ctx context.Context, blockNumber *big.Int, address common.Address) (*chain.ProofResponse, error) {
+	called := m.MethodCalled("GetProof", ctx, blockNumber, address)
+	return called.Get(0).(*chain.ProofResponse), called.Error(1)
+}
+
+// newMockL2Provider returns an L2 provider whose block at the given height holds the message passer with the given storage root, or fails with the given error.
+func newMockL2Provider(name string, l2BlockNumber uint64, storageRoot common.Hash, err error) *l2Provider {
+	stateRoot, proof := newMessagePasserState(storageRoot)
+	parent := newMockL2Header(l2BlockNumber-1, common.Hash{}, common.Hash{})
+	header := newMockL2Header(l2BlockNumber, parent.Hash(), stateRoot)
+	client := new(mockL2ChainAPIClient)
+	client.On("GetLatestBlockNumber", mock.Anything).Return(l2BlockNumber, err)
+	client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber))).Return(header, nil)
+	client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber-1))).Return(parent, nil)
+	client.On("GetBlockHeaderByHash", mock.Anything, header.Hash()).Return(header, nil)
+	client.On("GetProof", mock.Anything, mock.Anything, mock.Anything).Return(proof, nil)
+
+	return &l2Provider{name: name, client: client}
+}
+
+// newMockL2Header returns the header of an L2 block with the given height, parent hash and state root.
+func newMockL2Header(l2BlockNumber uint64, parentHash common.Hash, stateRoot common.Hash) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(l2BlockNumber), ParentHash: parentHash, Root: stateRoot, Difficulty: big.NewInt(0)}
+}
+
+func TestProofVerifier_ComputeOutputRoot(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	stateRoot := randHash()
+	otherStateRoot := randHash()
+	providerErr := fmt.Errorf("Failed to query latest block number")
+
+	tests := []struct {
+		name                  string
+		providers             []*l2Provider
+		quorum                uint
+		expectedStateRoot     common.Hash
+		expectedEndpoints     []string
+		expectedInconsistency bool
+		expectedQuorumError   bool
+	}{
+		{
+			name: "should return the output root when all the providers agree",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("b", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, nil),
+			},
+			quorum:            2,
+			expectedStateRoot: stateRoot,
+			expectedEndpoints: []string{"a", "b", "c"},
+		},
+		{
+			name: "should return the output root agreed by the quorum and the inconsistency when a provider disagrees",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("b", l2BlockNumber, otherStateRoot, nil),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, nil),
+			},
+			quorum:                2,
+			expectedStateRoot:     stateRoot,
+			expectedEndpoints:     []string{"a", "c"},
+			expectedInconsistency: true,
+		},
+		{
+			name: "should return the output root agreed by the quorum when a provider fails",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, providerErr),
+				newMockL2Provider("b", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, nil),
+			},
+			quorum:            2,
+			expectedStateRoot: stateRoot,
+			expectedEndpoints: []string{"b", "c"},
+		},
+		{
+			name: "should return quorum error with the inconsistency when the providers disagree",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("b", l2BlockNumber, otherStateRoot, nil),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, providerErr),
+			},
+			quorum:                2,
+			expectedInconsistency: true,
+			expectedQuorumError:   true,
+		},
+		{
+			name: "should return quorum error with the inconsistency when different output roots reach the quorum",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("b", l2BlockNumber, otherStateRoot, nil),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("d", l2BlockNumber, otherStateRoot, nil),
+			},
+			quorum:                2,
+			expectedInconsistency: true,
+			expectedQuorumError:   true,
+		},
+		{
+			name: "should return quorum error when not enough providers respond",
+			providers: []*l2Provider{
+				newMockL2Provider("a", l2BlockNumber, stateRoot, nil),
+				newMockL2Provider("b", l2BlockNumber, stateRoot, providerErr),
+				newMockL2Provider("c", l2BlockNumber, stateRoot, providerErr),
+			},
+			quorum:              2,
+			expectedQuorumError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			verifier := &proofVerifier{
+				ctx:       context.Background(),
+				logger:    logger,
+				metrics:   NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				providers: test.providers,
+				quorum:    test.quorum,
+			}
+
+			output, inconsistency, err := verifier.computeOutputRoot(5, l2BlockNumber)
+			if test.expectedQuorumError {
+				var qErr *quorumError
+				require.True(t, errors.As(err, &qErr))
+				require.Nil(t, output)
+				require.Equal(t, test.expectedInconsistency, qErr.inconsistency != nil)
+				return
+			}
+
+			require.NoError(t, err)
+			expectedOutput, _ := verifier.computeProviderOutputRoot(newMockL2Provider("expected", l2BlockNumber, test.expectedStateRoot, nil), l2BlockNumber)
+			require.Equal(t, expectedOutput.outputRoot, output.outputRoot)
+			require.Equal(t, test.expectedEndpoints, output.endpoints)
+			require.Equal(t, test.expectedInconsistency, inconsistency != nil)
+		})
+	}
+}
+
+func TestProofVerifier_ComputeProviderOutputRoot_InvalidProof(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	_, proof := newMessagePasserState(randHash())
+	parent := newMockL2Header(l2BlockNumber-1, common.Hash{}, common.Hash{})
+	header := newMockL2Header(l2BlockNumber, parent.Hash(), randHash())
+	client := new(mockL2ChainAPIClient)
+	client.On("GetLatestBlockNumber", mock.Anything).Return(l2BlockNumber, nil)
+	client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber))).Return(header, nil)
+	client.On("GetBlockHeaderByNumber", mock.Anything, big.NewInt(int64(l2BlockNumber-1))).Return(parent, nil)
+	client.On("GetBlockHeaderByHash", mock.Anything, header.Hash()).Return(header, nil)
+	client.On("GetProof", mock.Anything, mock.Anything, mock.Anything).Return(proof, nil)
+
+	logger, _ := log.NewDefaultProductionLogger()
+	verifier := &proofVerifier{
+		ctx:     context.Background(),
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+	}
+
+	output, err := verifier.computeProviderOutputRoot(&l2Provider{name: "a", client: client}, l2BlockNumber)
+	require.Nil(t, output)
+	require.ErrorIs(t, err, errInvalidProof)
+	require.Equal(t, float64(1), testutil.ToFloat64(verifier.metrics.rpcIntegrityFailure))
+	require.Equal(t, float64(0), testutil.ToFloat64(verifier.metrics.apiConnectionFailure))
+}
+
+func TestReportNodeInconsistency(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+	}
+
+	inconsistency := &nodeInconsistency{
+		outputIndex:   5,
+		l2BlockNumber: 1000,
+		outputRoots:   map[string]string{"a": randHash().String(), "b": randHash().String()},
+	}
+	fd.reportNodeInconsistency(nil)
+	fd.reportNodeInconsistency(inconsistency)
+	fd.reportNodeInconsistency(inconsistency)
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.nodeInconsistency))
+
+	fd.reportNodeInconsistency(&nodeInconsistency{
+		outputIndex:   6,
+		l2BlockNumber: 1010,
+		outputRoots:   map[string]string{"a": randHash().String(), "b": randHash().String()},
+	})
+	require.Equal(t, float64(2), testutil.ToFloat64(fd.metrics.nodeInconsistency))
+}
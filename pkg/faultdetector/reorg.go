@@ -0,0 +1,92 @@
+package faultdetector
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/encoding"
+)
+
+// verifiedOutput holds the output root of the last verified output, to detect its proposal disappearing from the oracle after an L1 reorg.
+type verifiedOutput struct {
+	outputIndex uint64
+	outputRoot  string
+}
+
+// pinL1Block pins the oracle reads to the L1 block at the configured read depth, when supported by the oracle accessor.
+func (fd *FaultDetector) pinL1Block() error {
+	pinner, ok := fd.oracleContractAccessor.(L1BlockPinner)
+	if !ok {
+		return nil
+	}
+
+	header, err := pinner.PinL1Block(fd.ctx)
+	if err != nil {
+		fd.logger.Errorf("Failed to pin L1 block for the oracle reads, error: %v.", err)
+		fd.metrics.apiConnectionFailure.Inc()
+		return err
+	}
+
+	fd.logger.Debugf("Oracle reads are pinned to L1 block with height: %d and hash: %s.", header.Number, header.Hash())
+	fd.pinnedL1Block = header
+	return nil
+}
+
+// checkReorgedOutputs detects the verified or diverged outputs whose proposal disappeared from the oracle after an L1 reorg.
+// The current output index is rewound, so that the outputs proposed in their place are verified.
+// It is only performed when the oracle reads are pinned and the pinned L1 block changed since the last check.
+func (fd *FaultDetector) checkReorgedOutputs(nextOutputIndex uint64) error {
+	if fd.pinnedL1Block == nil || fd.pinnedL1Block.Hash() == fd.lastReorgCheckL1Block {
+		return nil
+	}
+
+	// The faulty proposals do not exist anymore, the outputs proposed in their place are verified once available
+	reorgedDivergedOutputs := fd.removeDivergedOutputs(nextOutputIndex, math.MaxUint64)
+	for _, divergedOutput := range reorgedDivergedOutputs {
+		fd.reportReorgedOutput(divergedOutput.OutputIndex, divergedOutput.ExpectedOutputRoot)
+		fd.resolveFaults(divergedOutput.OutputIndex, divergedOutput.OutputIndex, FaultResolutionReorged)
+	}
+	if len(reorgedDivergedOutputs) > 0 {
+		if fd.currentOutputIndex > nextOutputIndex {
+			fd.logger.Infof("Rewinding current output index from %d to %d.", fd.currentOutputIndex, nextOutputIndex)
+			fd.currentOutputIndex = nextOutputIndex
+			fd.rewindLastVerifiedIndex(nextOutputIndex)
+		}
+		fd.saveCheckpoint()
+	}
+
+	if fd.lastVerifiedOutput != nil {
+		outputIndex := fd.lastVerifiedOutput.outputIndex
+		reorged := outputIndex >= nextOutputIndex
+		if !reorged {
+			l2OutputData, err := fd.oracleContractAccessor.GetL2Output(encoding.MustConvertUint64ToBigInt(outputIndex))
+			if err != nil {
+				fd.logger.Errorf("Failed to fetch output associated with index: %d, error: %v.", outputIndex, err)
+				fd.metrics.apiConnectionFailure.Inc()
+				return err
+			}
+			reorged = l2OutputData.OutputRoot != fd.lastVerifiedOutput.outputRoot
+		}
+
+		if reorged {
+			fd.reportReorgedOutput(outputIndex, fd.lastVerifiedOutput.outputRoot)
+
+			rewindIndex := min(outputIndex, nextOutputIndex)
+			fd.logger.Infof("Rewinding current output index from %d to %d.", fd.currentOutputIndex, rewindIndex)
+			fd.currentOutputIndex = rewindIndex
+			fd.rewindLastVerifiedIndex(rewindIndex)
+			fd.lastVerifiedOutput = nil
+			fd.saveCheckpoint()
+		}
+	}
+
+	fd.lastReorgCheckL1Block = fd.pinnedL1Block.Hash()
+	return nil
+}
+
+// reportReorgedOutput alerts that the proposal of the given output disappeared from the oracle after an L1 reorg.
+func (fd *FaultDetector) reportReorgedOutput(outputIndex uint64, outputRoot string) {
+	fd.logger.Warningf("Proposal of output with index %d and output root %s disappeared from the oracle at L1 block with height %d, likely due to an L1 reorg.", outputIndex, outputRoot, fd.pinnedL1Block.Number)
+	fd.metrics.reorgedOutputs.Inc()
+	fd.notify(fmt.Sprintf("*Output proposal reorged out*, proposal disappeared from the oracle after an L1 reorg:\noutputIndex: %d\nOutputRoot: %s\nL1BlockNumber: %d\nL1BlockHash: %s", outputIndex, outputRoot, fd.pinnedL1Block.Number, fd.pinnedL1Block.Hash()))
+}
@@ -0,0 +1,111 @@
+package faultdetector
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockL1BlockPinner struct {
+	mockOracleAccessor
+}
+
+func (o *mockL1BlockPinner) PinL1Block(ctx context.Context) (*types.Header, error) {
+	called := o.MethodCalled("PinL1Block", ctx)
+	return called.Get(0).(*types.Header), called.Error(1)
+}
+
+func TestCheckReorgedOutputs(t *testing.T) {
+	verifiedOutputRoot := randHash().String()
+
+	tests := []struct {
+		name                       string
+		nextOutputIndex            uint64
+		oracleOutputRoot           string
+		divergedOutput             *DivergedOutput
+		expectedReorgedOutputs     float64
+		expectedCurrentOutputIndex uint64
+		expectedDiverged           bool
+	}{
+		{
+			name:                       "should not rewind when the verified output is still proposed",
+			nextOutputIndex:            11,
+			oracleOutputRoot:           verifiedOutputRoot,
+			expectedReorgedOutputs:     0,
+			expectedCurrentOutputIndex: 11,
+		},
+		{
+			name:                       "should rewind when the verified output is replaced by another proposal",
+			nextOutputIndex:            11,
+			oracleOutputRoot:           randHash().String(),
+			expectedReorgedOutputs:     1,
+			expectedCurrentOutputIndex: 10,
+		},
+		{
+			name:                       "should rewind when the verified output is no longer proposed",
+			nextOutputIndex:            9,
+			expectedReorgedOutputs:     1,
+			expectedCurrentOutputIndex: 9,
+		},
+		{
+			name:                       "should clear the diverged output when it is no longer proposed",
+			nextOutputIndex:            11,
+			oracleOutputRoot:           verifiedOutputRoot,
+			divergedOutput:             &DivergedOutput{OutputIndex: 11, ExpectedOutputRoot: randHash().String()},
+			expectedReorgedOutputs:     1,
+			expectedCurrentOutputIndex: 11,
+			expectedDiverged:           false,
+		},
+		{
+			name:                       "should keep the diverged output when it is still proposed",
+			nextOutputIndex:            12,
+			oracleOutputRoot:           verifiedOutputRoot,
+			divergedOutput:             &DivergedOutput{OutputIndex: 11, ExpectedOutputRoot: randHash().String()},
+			expectedReorgedOutputs:     0,
+			expectedCurrentOutputIndex: 11,
+			expectedDiverged:           true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			oracle := new(mockL1BlockPinner)
+			oracle.On("PinL1Block", mock.Anything).Return(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}, nil)
+			oracle.On("GetL2Output", big.NewInt(10)).Return(chain.L2Output{OutputRoot: test.oracleOutputRoot, L2OutputIndex: 10}, nil)
+
+			fd := &FaultDetector{
+				ctx:                    context.Background(),
+				logger:                 logger,
+				metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				oracleContractAccessor: oracle,
+				currentOutputIndex:     11,
+				lastVerifiedIndex:      uint64Ptr(10),
+				lastVerifiedOutput:     &verifiedOutput{outputIndex: 10, outputRoot: verifiedOutputRoot},
+				mutex:                  new(sync.RWMutex),
+			}
+			if test.divergedOutput != nil {
+				fd.addDivergedOutput(test.divergedOutput)
+			}
+
+			require.NoError(t, fd.pinL1Block())
+			require.NoError(t, fd.checkReorgedOutputs(test.nextOutputIndex))
+			require.Equal(t, test.expectedReorgedOutputs, testutil.ToFloat64(fd.metrics.reorgedOutputs))
+			require.Equal(t, test.expectedCurrentOutputIndex, fd.currentOutputIndex)
+			require.Equal(t, test.expectedDiverged, fd.diverged)
+
+			// The check is skipped until the pinned L1 block changes
+			require.NoError(t, fd.checkReorgedOutputs(0))
+			require.Equal(t, test.expectedReorgedOutputs, testutil.ToFloat64(fd.metrics.reorgedOutputs))
+		})
+	}
+}
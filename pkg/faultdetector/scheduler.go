@@ -0,0 +1,163 @@
+package faultdetector
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+)
+
+const defaultProposalIntervalInSeconds = 60
+
+var (
+	// errNoNewOutput is returned when all the outputs published to the oracle are already verified.
+	errNoNewOutput = errors.New("current output index is ahead of the oracle latest batch index")
+	// errL2NodeBehind is returned when the L2 node has not yet synced the L2 block of the output to verify.
+	errL2NodeBehind = errors.New("l2 node is behind")
+)
+
+// checkOutcome is the class of the result of a single check for the faults, each class is scheduled with its own policy.
+type checkOutcome int
+
+const (
+	// outcomeBehind is a successful check with more outputs left to verify.
+	outcomeBehind checkOutcome = iota
+	// outcomeDiverged is a successful check that found the verified output to diverge, regardless of the faults reported by the earlier checks.
+	outcomeDiverged
+	// outcomeNoNewOutput is a check that found no new output proposed since the last verified output.
+	outcomeNoNewOutput
+	// outcomeL2NodeBehind is a check that failed because the L2 node has not yet synced the L2 block of the output.
+	outcomeL2NodeBehind
+	// outcomeFailure is a check that failed for any other reason, e.g. RPC failures.
+	outcomeFailure
+)
+
+// String returns the name of the check outcome, as logged when scheduling the next check.
+func (o checkOutcome) String() string {
+	switch o {
+	case outcomeBehind:
+		return "behind"
+	case outcomeDiverged:
+		return "diverged"
+	case outcomeNoNewOutput:
+		return "no new output"
+	case outcomeL2NodeBehind:
+		return "l2 node behind"
+	default:
+		return "failure"
+	}
+}
+
+// classifyCheck returns the class of the result of a single check, based on the error returned by the check and whether the output diverged.
+func classifyCheck(err error, diverged bool) checkOutcome {
+	switch {
+	case errors.Is(err, errNoNewOutput):
+		return outcomeNoNewOutput
+	case errors.Is(err, errL2NodeBehind):
+		return outcomeL2NodeBehind
+	case err != nil:
+		return outcomeFailure
+	case diverged:
+		return outcomeDiverged
+	default:
+		return outcomeBehind
+	}
+}
+
+// periodicChecksInterval returns the smallest interval of the enabled periodic checks, i.e. the finalization escalation, the oracle parameters and the dispute game status checks, or 0 when none is enabled.
+// These checks run within the check for the faults, which is therefore scheduled at least at this interval.
+func periodicChecksInterval(oracleContractAccessor OracleAccessor, oracleParametersInterval time.Duration, escalationThresholds []*config.EscalationThreshold) time.Duration {
+	var intervals []time.Duration
+	if len(escalationThresholds) > 0 {
+		intervals = append(intervals, finalizationEscalationCheckInterval)
+	}
+	if oracleParametersInterval > 0 {
+		intervals = append(intervals, oracleParametersInterval)
+	}
+	if _, ok := oracleContractAccessor.(DisputeGameAccessor); ok {
+		intervals = append(intervals, disputeGameStatusCheckInterval)
+	}
+
+	var interval time.Duration
+	for _, i := range intervals {
+		if interval == 0 || i < interval {
+			interval = i
+		}
+	}
+	return interval
+}
+
+// scheduler computes the interval until the next check for the faults, applying a different policy to every class of check outcome.
+// Failures are retried with exponential backoff and jitter, the backoff is reset by any other outcome.
+// Every interval is capped at the max interval, so that the periodic checks run within the check for the faults are not delayed while waiting for the new proposals.
+type scheduler struct {
+	behindInterval         time.Duration
+	proposalInterval       time.Duration
+	faultRecheckInterval   time.Duration
+	l2NodeBehindInterval   time.Duration
+	backoffInitialInterval time.Duration
+	backoffMaxInterval     time.Duration
+	backoffMultiplier      float64
+	backoffJitter          float64
+	maxInterval            time.Duration
+	failures               int
+	random                 func() float64
+}
+
+// newScheduler returns the scheduler with the configured intervals, waiting the given proposal interval for the new proposals.
+// The intervals are capped at maxInterval, unless it is 0.
+func newScheduler(cfg *config.Scheduler, proposalInterval time.Duration, maxInterval time.Duration) *scheduler {
+	return &scheduler{
+		behindInterval:         cfg.BehindInterval,
+		proposalInterval:       proposalInterval,
+		faultRecheckInterval:   cfg.FaultRecheckInterval,
+		l2NodeBehindInterval:   cfg.L2NodeBehindInterval,
+		backoffInitialInterval: cfg.BackoffInitialInterval,
+		backoffMaxInterval:     cfg.BackoffMaxInterval,
+		backoffMultiplier:      cfg.BackoffMultiplier,
+		backoffJitter:          cfg.BackoffJitter,
+		maxInterval:            maxInterval,
+		random:                 rand.Float64,
+	}
+}
+
+// next returns the interval until the next check after a check with the given outcome.
+func (s *scheduler) next(outcome checkOutcome) time.Duration {
+	interval := s.interval(outcome)
+	if s.maxInterval > 0 && interval > s.maxInterval {
+		return s.maxInterval
+	}
+	return interval
+}
+
+// interval returns the interval of the policy of the given check outcome, before it is capped.
+func (s *scheduler) interval(outcome checkOutcome) time.Duration {
+	if outcome != outcomeFailure {
+		s.failures = 0
+	}
+
+	switch outcome {
+	case outcomeBehind:
+		return s.behindInterval
+	case outcomeDiverged:
+		return s.faultRecheckInterval
+	case outcomeNoNewOutput:
+		return s.proposalInterval
+	case outcomeL2NodeBehind:
+		return s.l2NodeBehindInterval
+	default:
+		s.failures++
+		return s.backoff()
+	}
+}
+
+// backoff returns the exponential backoff interval for the current number of consecutive failures, with a random jitter of up to the given fraction of the interval in either direction.
+func (s *scheduler) backoff() time.Duration {
+	interval := float64(s.backoffInitialInterval) * math.Pow(s.backoffMultiplier, float64(s.failures-1))
+	interval = math.Min(interval, float64(s.backoffMaxInterval))
+	interval += interval * s.backoffJitter * (2*s.random() - 1)
+
+	return time.Duration(math.Min(interval, float64(s.backoffMaxInterval)))
+}
@@ -0,0 +1,159 @@
+package faultdetector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		diverged        bool
+		expectedOutcome checkOutcome
+	}{
+		{
+			name:            "should classify a successful check as behind",
+			expectedOutcome: outcomeBehind,
+		},
+		{
+			name:            "should classify a successful check of a diverged output as diverged",
+			diverged:        true,
+			expectedOutcome: outcomeDiverged,
+		},
+		{
+			name:            "should classify no new output",
+			err:             errNoNewOutput,
+			expectedOutcome: outcomeNoNewOutput,
+		},
+		{
+			name:            "should classify L2 node behind",
+			err:             fmt.Errorf("failed to verify output: %w", errL2NodeBehind),
+			expectedOutcome: outcomeL2NodeBehind,
+		},
+		{
+			name:            "should classify L2 node behind when the quorum is not reached due to it",
+			err:             &quorumError{quorum: 2, agreed: 1, providers: 2, errs: []error{nil, errL2NodeBehind}},
+			expectedOutcome: outcomeL2NodeBehind,
+		},
+		{
+			name:            "should classify any other error as failure",
+			err:             fmt.Errorf("503 Service Unavailable"),
+			diverged:        true,
+			expectedOutcome: outcomeFailure,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedOutcome, classifyCheck(test.err, test.diverged))
+		})
+	}
+}
+
+func TestScheduler_Next(t *testing.T) {
+	cfg := (&config.FaultDetectorConfig{
+		Scheduler: &config.Scheduler{
+			BackoffInitialInterval: time.Second,
+			BackoffMaxInterval:     10 * time.Second,
+			BackoffMultiplier:      2,
+			BackoffJitter:          0.5,
+		},
+	}).GetScheduler()
+
+	t.Run("should apply the interval of every outcome", func(t *testing.T) {
+		s := newScheduler(cfg, time.Hour, 0)
+
+		require.Equal(t, time.Duration(0), s.next(outcomeBehind))
+		require.Equal(t, cfg.FaultRecheckInterval, s.next(outcomeDiverged))
+		require.Equal(t, time.Hour, s.next(outcomeNoNewOutput))
+		require.Equal(t, cfg.L2NodeBehindInterval, s.next(outcomeL2NodeBehind))
+	})
+
+	t.Run("should back off exponentially up to the max interval and reset on success", func(t *testing.T) {
+		s := newScheduler(cfg, time.Hour, 0)
+		s.random = func() float64 { return 0.5 }
+
+		require.Equal(t, time.Second, s.next(outcomeFailure))
+		require.Equal(t, 2*time.Second, s.next(outcomeFailure))
+		require.Equal(t, 4*time.Second, s.next(outcomeFailure))
+		require.Equal(t, 8*time.Second, s.next(outcomeFailure))
+		require.Equal(t, 10*time.Second, s.next(outcomeFailure))
+		require.Equal(t, 10*time.Second, s.next(outcomeFailure))
+
+		s.next(outcomeBehind)
+		require.Equal(t, time.Second, s.next(outcomeFailure))
+	})
+
+	t.Run("should apply the jitter within the given fraction of the interval", func(t *testing.T) {
+		s := newScheduler(cfg, time.Hour, 0)
+
+		s.random = func() float64 { return 0 }
+		require.Equal(t, 500*time.Millisecond, s.next(outcomeFailure))
+
+		s.random = func() float64 { return 1 }
+		require.Equal(t, 3*time.Second, s.next(outcomeFailure))
+
+		// The jitter never exceeds the max interval
+		s.failures = 10
+		require.Equal(t, 10*time.Second, s.next(outcomeFailure))
+	})
+	t.Run("should cap every interval at the max interval", func(t *testing.T) {
+		s := newScheduler(cfg, time.Hour, 5*time.Second)
+		s.random = func() float64 { return 0.5 }
+
+		require.Equal(t, time.Duration(0), s.next(outcomeBehind))
+		require.Equal(t, 5*time.Second, s.next(outcomeDiverged))
+		require.Equal(t, 5*time.Second, s.next(outcomeNoNewOutput))
+		require.Equal(t, time.Second, s.next(outcomeFailure))
+
+		s.failures = 10
+		require.Equal(t, 5*time.Second, s.next(outcomeFailure))
+	})
+}
+
+func TestPeriodicChecksInterval(t *testing.T) {
+	thresholds := []*config.EscalationThreshold{{Before: time.Hour, Severity: "critical"}}
+
+	tests := []struct {
+		name                     string
+		oracle                   OracleAccessor
+		oracleParametersInterval time.Duration
+		escalationThresholds     []*config.EscalationThreshold
+		expected                 time.Duration
+	}{
+		{
+			name:     "should not cap the intervals without periodic checks",
+			oracle:   new(mockOracleAccessor),
+			expected: 0,
+		},
+		{
+			name:                     "should return the oracle parameters interval",
+			oracle:                   new(mockOracleAccessor),
+			oracleParametersInterval: 5 * time.Minute,
+			expected:                 5 * time.Minute,
+		},
+		{
+			name:                     "should return the smallest interval of the enabled checks",
+			oracle:                   new(mockOracleAccessor),
+			oracleParametersInterval: 5 * time.Minute,
+			escalationThresholds:     thresholds,
+			expected:                 finalizationEscalationCheckInterval,
+		},
+		{
+			name:     "should return the dispute game status interval",
+			oracle:   new(mockDisputeGameAccessor),
+			expected: disputeGameStatusCheckInterval,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, periodicChecksInterval(test.oracle, test.oracleParametersInterval, test.escalationThresholds))
+		})
+	}
+}
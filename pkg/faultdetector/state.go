@@ -0,0 +1,79 @@
+package faultdetector
+
+import (
+	"time"
+)
+
+// State of the fault detector, as observed after the last check for the faults.
+type State string
+
+// States of the fault detector.
+const (
+	// StateStarting is the state before the first check.
+	StateStarting State = "starting"
+	// StateIdle is the state when all the proposed outputs are verified and the detector is waiting for the new proposals.
+	StateIdle State = "idle"
+	// StateCatchingUp is the state when there are proposed outputs left to verify.
+	StateCatchingUp State = "catching_up"
+	// StateWaitingForL2Node is the state when the L2 node has not yet synced the L2 block of the output to verify.
+	StateWaitingForL2Node State = "waiting_for_l2_node"
+	// StateFailing is the state when the checks fail for any other reason, e.g. RPC failures.
+	StateFailing State = "failing"
+	// StateDiverged is the state when the output root published to the oracle does not match the local view.
+	StateDiverged State = "diverged"
+)
+
+// States lists all the states of the fault detector.
+var States = []State{StateStarting, StateIdle, StateCatchingUp, StateWaitingForL2Node, StateFailing, StateDiverged}
+
+// stateFromOutcome returns the state of the fault detector after a check with the given outcome.
+func stateFromOutcome(outcome checkOutcome) State {
+	switch outcome {
+	case outcomeBehind:
+		return StateCatchingUp
+	case outcomeDiverged:
+		return StateDiverged
+	case outcomeNoNewOutput:
+		return StateIdle
+	case outcomeL2NodeBehind:
+		return StateWaitingForL2Node
+	default:
+		return StateFailing
+	}
+}
+
+// setState transitions the fault detector to the given state, logging the transition and updating the state metric.
+// The transition time is only updated when the state changes.
+func (fd *FaultDetector) setState(state State) {
+	fd.mutex.Lock()
+	prevState, prevStateSince := fd.state, fd.stateSince
+	if prevState == state {
+		fd.mutex.Unlock()
+		return
+	}
+	now := time.Now()
+	fd.state = state
+	fd.stateSince = now
+	fd.mutex.Unlock()
+
+	for _, s := range States {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		fd.metrics.state.WithLabelValues(string(s)).Set(value)
+	}
+
+	if len(prevState) == 0 {
+		fd.logger.Infof("Fault detector state is set to %s.", state)
+		return
+	}
+	fd.logger.Infof("Fault detector state changed from %s to %s after %s.", prevState, state, now.Sub(prevStateSince).Round(time.Second))
+}
+
+// State returns the current state of the fault detector and the time it transitioned to it.
+func (fd *FaultDetector) State() (State, time.Time) {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	return fd.state, fd.stateSince
+}
@@ -0,0 +1,52 @@
+package faultdetector
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateFromOutcome(t *testing.T) {
+	require.Equal(t, StateCatchingUp, stateFromOutcome(outcomeBehind))
+	require.Equal(t, StateDiverged, stateFromOutcome(outcomeDiverged))
+	require.Equal(t, StateIdle, stateFromOutcome(outcomeNoNewOutput))
+	require.Equal(t, StateWaitingForL2Node, stateFromOutcome(outcomeL2NodeBehind))
+	require.Equal(t, StateFailing, stateFromOutcome(outcomeFailure))
+}
+
+func TestSetState(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	fd := &FaultDetector{
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:   new(sync.RWMutex),
+	}
+
+	fd.setState(StateStarting)
+	state, startingSince := fd.State()
+	require.Equal(t, StateStarting, state)
+	require.False(t, startingSince.IsZero())
+
+	fd.setState(StateCatchingUp)
+	state, catchingUpSince := fd.State()
+	require.Equal(t, StateCatchingUp, state)
+	require.False(t, catchingUpSince.Before(startingSince))
+
+	// The transition time is kept while the state does not change
+	fd.setState(StateCatchingUp)
+	_, since := fd.State()
+	require.Equal(t, catchingUpSince, since)
+
+	fd.setState(StateIdle)
+	for _, s := range States {
+		expected := 0.0
+		if s == StateIdle {
+			expected = 1
+		}
+		require.Equal(t, expected, testutil.ToFloat64(fd.metrics.state.WithLabelValues(string(s))), s)
+	}
+}
@@ -0,0 +1,102 @@
+package faultdetector
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// subscribeOutputProposed subscribes to the outputs proposed to the oracle, when supported by the oracle accessor and the L1 provider.
+// The outputs deleted from the oracle are subscribed to as well, when supported by the oracle accessor.
+// It returns false when the fault detector has to keep polling the oracle for the new outputs.
+func (fd *FaultDetector) subscribeOutputProposed() bool {
+	if fd.outputSubscriber == nil {
+		return false
+	}
+
+	fd.lastSubscribeAttempt = time.Now()
+	sub, err := fd.outputSubscriber.SubscribeOutputProposed(fd.ctx, fd.proposedOutputs)
+	if errors.Is(err, chain.ErrSubscriptionNotSupported) {
+		fd.logger.Infof("L1 provider does not support subscriptions, polling for the proposed outputs instead.")
+		fd.outputSubscriber = nil
+		return false
+	}
+	if err != nil {
+		fd.logger.Errorf("Failed to subscribe to the proposed outputs, polling until resubscribed, error: %v.", err)
+		fd.metrics.apiConnectionFailure.Inc()
+		return false
+	}
+
+	if deletedSubscriber, ok := fd.oracleContractAccessor.(OutputsDeletedSubscriber); ok {
+		deletedSub, err := deletedSubscriber.SubscribeOutputsDeleted(fd.ctx, fd.deletedOutputs)
+		if err != nil {
+			sub.Unsubscribe()
+			fd.logger.Errorf("Failed to subscribe to the deleted outputs, polling until resubscribed, error: %v.", err)
+			fd.metrics.apiConnectionFailure.Inc()
+			return false
+		}
+		sub = event.JoinSubscriptions(sub, deletedSub)
+	}
+
+	fd.outputSubscription = sub
+	return true
+}
+
+// resubscribeOutputProposed retries the subscription to the proposed outputs every resubscribe interval, after it has failed.
+func (fd *FaultDetector) resubscribeOutputProposed() {
+	if fd.outputSubscriber == nil || fd.outputSubscription != nil || time.Since(fd.lastSubscribeAttempt) < resubscribeIntervalInSeconds*time.Second {
+		return
+	}
+
+	if fd.subscribeOutputProposed() {
+		fd.logger.Infof("Resubscribed to the proposed outputs.")
+	}
+}
+
+// awaitingProposedOutput returns true while the proposed output received from the subscription is not yet visible to the oracle reads, i.e. the check found no new output and the L1 block pinned at the L1 read depth precedes the proposal.
+// The awaited output is cleared once a new output is found or the pinned L1 block reaches the proposal, e.g. when the proposal was reorged out.
+func (fd *FaultDetector) awaitingProposedOutput(outcome checkOutcome) bool {
+	if fd.awaitedOutput == nil {
+		return false
+	}
+
+	switch {
+	case outcome == outcomeFailure || outcome == outcomeL2NodeBehind:
+		// The check did not reach the oracle outputs, the output is still awaited
+		return false
+	case outcome == outcomeNoNewOutput && fd.pinnedL1Block != nil && fd.pinnedL1Block.Time < fd.awaitedOutput.L1Timestamp:
+		fd.logger.Infof("Proposed output with index: %d is not yet visible at the L1 read depth, pinned L1 block with height: %d.", fd.awaitedOutput.L2OutputIndex, fd.pinnedL1Block.Number)
+		return true
+	default:
+		fd.awaitedOutput = nil
+		return false
+	}
+}
+
+// outputSubscriptionErr returns the error channel of the subscription to the proposed outputs, or nil when not subscribed.
+func (fd *FaultDetector) outputSubscriptionErr() <-chan error {
+	if fd.outputSubscription == nil {
+		return nil
+	}
+	return fd.outputSubscription.Err()
+}
+
+// handleOutputSubscriptionError falls back to polling for the proposed outputs when the subscription fails.
+// The oracle is checked right away, to reconcile any output proposed while the subscription was failing.
+func (fd *FaultDetector) handleOutputSubscriptionError(err error) {
+	fd.logger.Errorf("Subscription to the proposed outputs failed, polling until resubscribed, error: %v.", err)
+	fd.metrics.apiConnectionFailure.Inc()
+	fd.outputSubscription = nil
+	fd.scheduleCheck(0)
+}
+
+// unsubscribeOutputProposed cancels the subscription to the proposed outputs, if any.
+func (fd *FaultDetector) unsubscribeOutputProposed() {
+	if fd.outputSubscription == nil {
+		return
+	}
+	fd.outputSubscription.Unsubscribe()
+	fd.outputSubscription = nil
+}
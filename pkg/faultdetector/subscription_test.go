@@ -0,0 +1,165 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/config"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutputSubscriber struct {
+	mockOracleAccessor
+}
+
+func (o *mockOutputSubscriber) SubscribeOutputProposed(ctx context.Context, sink chan<- chain.L2Output) (event.Subscription, error) {
+	called := o.MethodCalled("SubscribeOutputProposed", ctx, sink)
+	sub, _ := called.Get(0).(event.Subscription)
+	return sub, called.Error(1)
+}
+
+func TestSubscribeOutputProposed(t *testing.T) {
+	tests := []struct {
+		name                string
+		subscribeErr        error
+		expectedSubscribed  bool
+		expectedSubscriber  bool
+		expectedAPIFailures float64
+	}{
+		{
+			name:               "should subscribe when supported by the L1 provider",
+			expectedSubscribed: true,
+			expectedSubscriber: true,
+		},
+		{
+			name:               "should poll and stop subscribing when subscriptions are not supported",
+			subscribeErr:       chain.ErrSubscriptionNotSupported,
+			expectedSubscribed: false,
+			expectedSubscriber: false,
+		},
+		{
+			name:                "should poll and keep the subscriber for resubscription when subscription fails",
+			subscribeErr:        fmt.Errorf("Failed to subscribe"),
+			expectedSubscribed:  false,
+			expectedSubscriber:  true,
+			expectedAPIFailures: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := log.NewDefaultProductionLogger()
+			subscriber := new(mockOutputSubscriber)
+			var sub event.Subscription
+			if test.subscribeErr == nil {
+				sub = event.NewSubscription(func(quit <-chan struct{}) error {
+					<-quit
+					return nil
+				})
+			}
+			subscriber.On("SubscribeOutputProposed", mock.Anything, mock.Anything).Return(sub, test.subscribeErr)
+
+			fd := &FaultDetector{
+				ctx:              context.Background(),
+				logger:           logger,
+				metrics:          NewFaultDetectorMetrics(prometheus.NewRegistry()),
+				outputSubscriber: subscriber,
+				proposedOutputs:  make(chan chain.L2Output),
+			}
+
+			require.Equal(t, test.expectedSubscribed, fd.subscribeOutputProposed())
+			require.Equal(t, test.expectedSubscribed, fd.outputSubscription != nil)
+			require.Equal(t, test.expectedSubscriber, fd.outputSubscriber != nil)
+			require.Equal(t, test.expectedAPIFailures, testutil.ToFloat64(fd.metrics.apiConnectionFailure))
+
+			fd.unsubscribeOutputProposed()
+			require.Nil(t, fd.outputSubscription)
+		})
+	}
+}
+
+func TestHandleOutputSubscriptionError(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	subscriber := new(mockOutputSubscriber)
+	subscriber.On("SubscribeOutputProposed", mock.Anything, mock.Anything).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		return fmt.Errorf("connection lost")
+	}), nil)
+
+	fd := &FaultDetector{
+		ctx:              context.Background(),
+		logger:           logger,
+		metrics:          NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		timer:            time.NewTimer(time.Hour),
+		outputSubscriber: subscriber,
+		proposedOutputs:  make(chan chain.L2Output),
+	}
+	defer fd.timer.Stop()
+
+	require.True(t, fd.subscribeOutputProposed())
+	fd.handleOutputSubscriptionError(<-fd.outputSubscriptionErr())
+	require.Nil(t, fd.outputSubscription)
+	require.Nil(t, fd.outputSubscriptionErr())
+	require.Equal(t, float64(1), testutil.ToFloat64(fd.metrics.apiConnectionFailure))
+
+	// The oracle is checked right away after the subscription fails
+	select {
+	case <-fd.timer.C:
+	case <-time.After(time.Second):
+		t.Fatal("expected the check to be scheduled right away")
+	}
+
+	// Resubscription is only attempted after the resubscribe interval
+	fd.resubscribeOutputProposed()
+	require.Nil(t, fd.outputSubscription)
+	fd.lastSubscribeAttempt = time.Now().Add(-resubscribeIntervalInSeconds * time.Second)
+	fd.resubscribeOutputProposed()
+	require.NotNil(t, fd.outputSubscription)
+	subscriber.AssertNumberOfCalls(t, "SubscribeOutputProposed", 2)
+}
+
+func TestNextCheckInterval_AwaitedOutput(t *testing.T) {
+	logger, _ := log.NewDefaultProductionLogger()
+	oracle := new(mockL1BlockPinner)
+	// The oracle reads are pinned at the L1 read depth, behind the L1 block of the proposal
+	oracle.On("PinL1Block", mock.Anything).Return(&types.Header{Number: big.NewInt(100), Time: 1000, Difficulty: big.NewInt(0)}, nil).Once()
+	oracle.On("PinL1Block", mock.Anything).Return(&types.Header{Number: big.NewInt(105), Time: 1060, Difficulty: big.NewInt(0)}, nil).Once()
+	oracle.On("GetNextOutputIndex").Return(big.NewInt(10), nil)
+
+	cfg := (&config.FaultDetectorConfig{}).GetScheduler()
+	fd := &FaultDetector{
+		ctx:                    context.Background(),
+		logger:                 logger,
+		metrics:                NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		mutex:                  new(sync.RWMutex),
+		oracleContractAccessor: oracle,
+		currentOutputIndex:     10,
+		scheduler:              newScheduler(cfg, time.Hour, 0),
+		awaitedOutput:          &chain.L2Output{L2OutputIndex: 10, L1Timestamp: 1050},
+	}
+
+	// The proposed output is not yet visible to the pinned oracle reads, it is checked again soon
+	diverged, err := fd.checkFault()
+	require.ErrorIs(t, err, errNoNewOutput)
+	require.Equal(t, cfg.L2NodeBehindInterval, fd.nextCheckInterval(classifyCheck(err, diverged)))
+
+	// The failures are retried with backoff, the output is still awaited
+	require.LessOrEqual(t, fd.nextCheckInterval(outcomeFailure), cfg.L2NodeBehindInterval)
+	require.NotNil(t, fd.awaitedOutput)
+
+	// The pinned L1 block reached the proposal, which was reorged out, the new proposals are awaited again
+	diverged, err = fd.checkFault()
+	require.ErrorIs(t, err, errNoNewOutput)
+	require.Equal(t, time.Hour, fd.nextCheckInterval(classifyCheck(err, diverged)))
+	require.Nil(t, fd.awaitedOutput)
+}
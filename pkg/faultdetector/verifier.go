@@ -0,0 +1,100 @@
+package faultdetector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// outputVerifier computes the output root of an L2 block from the local view, to be compared with the output root published to the oracle.
+// Along with the output root, it returns the disagreement between the queried nodes, if any.
+type outputVerifier interface {
+	computeOutputRoot(outputIndex uint64, l2BlockNumber uint64) (*providerOutput, *nodeInconsistency, error)
+}
+
+// RollupNodeAPIClient is implemented by the rollup node API clients used to query the output roots.
+type RollupNodeAPIClient interface {
+	OutputAtBlock(ctx context.Context, blockNumber uint64) (*chain.OutputResponse, error)
+}
+
+// rollupNodeVerifier queries the output roots from a rollup node with `optimism_outputAtBlock`.
+// It does not require an archive L2 node serving `eth_getProof` for the old blocks.
+type rollupNodeVerifier struct {
+	ctx     context.Context
+	logger  log.Logger
+	metrics *faultDetectorMetrics
+	name    string
+	client  RollupNodeAPIClient
+}
+
+// computeOutputRoot returns the output root of the given L2 block as reported by the rollup node.
+func (v *rollupNodeVerifier) computeOutputRoot(outputIndex uint64, l2BlockNumber uint64) (*providerOutput, *nodeInconsistency, error) {
+	output, err := v.client.OutputAtBlock(v.ctx, l2BlockNumber)
+	if err != nil {
+		v.logger.Errorf("Failed to fetch output at block with height: %d from rollup node %s, error: %v.", l2BlockNumber, v.name, err)
+		v.metrics.apiConnectionFailure.Inc()
+		return nil, nil, err
+	}
+
+	if output.BlockRef.Number != l2BlockNumber {
+		v.logger.Errorf("Rollup node %s returned output at block with height: %d, expected: %d.", v.name, output.BlockRef.Number, l2BlockNumber)
+		return nil, nil, fmt.Errorf("rollup node returned output at unexpected block")
+	}
+
+	return &providerOutput{
+		outputRoot:     hexutil.Encode(output.OutputRoot[:]),
+		blockTimestamp: output.BlockRef.Timestamp,
+		endpoints:      []string{v.name},
+	}, nil, nil
+}
+
+// crossCheckVerifier computes the output roots with both the proof-based and the rollup node verifiers.
+// An output root is only accepted when both verifiers agree on it.
+type crossCheckVerifier struct {
+	logger     log.Logger
+	proof      outputVerifier
+	rollupNode outputVerifier
+}
+
+// verifierMismatchError is returned when the verifiers cross-checked computed different output roots.
+type verifierMismatchError struct {
+	inconsistency *nodeInconsistency
+}
+
+func (e *verifierMismatchError) Error() string {
+	return fmt.Sprintf("verifiers computed different output roots for the block with height: %d", e.inconsistency.l2BlockNumber)
+}
+
+// computeOutputRoot returns the output root of the given L2 block agreed on by both verifiers.
+func (v *crossCheckVerifier) computeOutputRoot(outputIndex uint64, l2BlockNumber uint64) (*providerOutput, *nodeInconsistency, error) {
+	proofOutput, inconsistency, err := v.proof.computeOutputRoot(outputIndex, l2BlockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rollupNodeOutput, _, err := v.rollupNode.computeOutputRoot(outputIndex, l2BlockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if proofOutput.outputRoot != rollupNodeOutput.outputRoot {
+		err := &verifierMismatchError{
+			inconsistency: &nodeInconsistency{
+				outputIndex:   outputIndex,
+				l2BlockNumber: l2BlockNumber,
+				outputRoots: map[string]string{
+					chain.VerifierTypeProof:      proofOutput.outputRoot,
+					chain.VerifierTypeRollupNode: rollupNodeOutput.outputRoot,
+				},
+			},
+		}
+		v.logger.Errorf("Failed to compute output root for the block with height: %d, error: %v.", l2BlockNumber, err)
+		return nil, nil, err
+	}
+
+	proofOutput.endpoints = append(proofOutput.endpoints, rollupNodeOutput.endpoints...)
+	return proofOutput, inconsistency, nil
+}
@@ -0,0 +1,139 @@
+package faultdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LiskHQ/op-fault-detector/pkg/chain"
+	"github.com/LiskHQ/op-fault-detector/pkg/log"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRollupNodeAPIClient struct {
+	mock.Mock
+}
+
+func (m *mockRollupNodeAPIClient) OutputAtBlock(ctx context.Context, blockNumber uint64) (*chain.OutputResponse, error) {
+	called := m.MethodCalled("OutputAtBlock", ctx, blockNumber)
+	return called.Get(0).(*chain.OutputResponse), called.Error(1)
+}
+
+// newMockRollupNodeVerifier returns a rollup node verifier reporting the output at the given block, or failing with the given error.
+func newMockRollupNodeVerifier(output *chain.OutputResponse, err error) *rollupNodeVerifier {
+	logger, _ := log.NewDefaultProductionLogger()
+	client := new(mockRollupNodeAPIClient)
+	client.On("OutputAtBlock", mock.Anything, mock.Anything).Return(output, err)
+
+	return &rollupNodeVerifier{
+		ctx:     context.Background(),
+		logger:  logger,
+		metrics: NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		name:    "op-node",
+		client:  client,
+	}
+}
+
+func TestRollupNodeVerifier_ComputeOutputRoot(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	outputRoot := randHash()
+
+	tests := []struct {
+		name               string
+		output             *chain.OutputResponse
+		outputErr          error
+		expectedOutputRoot string
+		expectedErr        bool
+	}{
+		{
+			name:               "should return the output root reported by the rollup node",
+			output:             &chain.OutputResponse{OutputRoot: outputRoot, BlockRef: chain.L2BlockRef{Number: l2BlockNumber, Timestamp: 100}},
+			expectedOutputRoot: hexutil.Encode(outputRoot[:]),
+		},
+		{
+			name:        "should return error when the rollup node reports the output at another block",
+			output:      &chain.OutputResponse{OutputRoot: outputRoot, BlockRef: chain.L2BlockRef{Number: l2BlockNumber + 1}},
+			expectedErr: true,
+		},
+		{
+			name:        "should return error when the rollup node query fails",
+			output:      &chain.OutputResponse{},
+			outputErr:   fmt.Errorf("Failed to fetch output"),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifier := newMockRollupNodeVerifier(test.output, test.outputErr)
+
+			output, inconsistency, err := verifier.computeOutputRoot(5, l2BlockNumber)
+			require.Nil(t, inconsistency)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expectedOutputRoot, output.outputRoot)
+			require.Equal(t, test.output.BlockRef.Timestamp, output.blockTimestamp)
+		})
+	}
+}
+
+func TestCrossCheckVerifier_ComputeOutputRoot(t *testing.T) {
+	const l2BlockNumber uint64 = 1000
+	logger, _ := log.NewDefaultProductionLogger()
+	proof := &proofVerifier{
+		ctx:       context.Background(),
+		logger:    logger,
+		metrics:   NewFaultDetectorMetrics(prometheus.NewRegistry()),
+		providers: []*l2Provider{newMockL2Provider("a", l2BlockNumber, randHash(), nil)},
+		quorum:    1,
+	}
+	proofOutput, _, err := proof.computeOutputRoot(5, l2BlockNumber)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		rollupNodeRoot      string
+		expectedMismatchErr bool
+	}{
+		{
+			name:           "should return the output root when both verifiers agree",
+			rollupNodeRoot: proofOutput.outputRoot,
+		},
+		{
+			name:                "should return mismatch error with the inconsistency when the verifiers disagree",
+			rollupNodeRoot:      randHash().String(),
+			expectedMismatchErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rollupNode := newMockRollupNodeVerifier(&chain.OutputResponse{
+				OutputRoot: [32]byte(hexutil.MustDecode(test.rollupNodeRoot)),
+				BlockRef:   chain.L2BlockRef{Number: l2BlockNumber},
+			}, nil)
+			verifier := &crossCheckVerifier{logger: logger, proof: proof, rollupNode: rollupNode}
+
+			output, _, err := verifier.computeOutputRoot(5, l2BlockNumber)
+			if test.expectedMismatchErr {
+				var mErr *verifierMismatchError
+				require.True(t, errors.As(err, &mErr))
+				require.Len(t, mErr.inconsistency.outputRoots, 2)
+				require.Nil(t, output)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, proofOutput.outputRoot, output.outputRoot)
+			require.Equal(t, []string{"a", "op-node"}, output.endpoints)
+		})
+	}
+}
@@ -44,13 +44,18 @@ func NewClient(ctx context.Context, logger log.Logger, slackConfig *config.Slack
 
 // Notify sends a message to the slack channel.
 func (s *Slack) Notify(msg string) error {
+	return s.NotifyChannel(s.channelID, msg)
+}
+
+// NotifyChannel sends a message to the given slack channel instead of the configured one.
+func (s *Slack) NotifyChannel(channelID string, msg string) error {
 	_, timestamp, err := s.client.PostMessageContext(
 		s.ctx,
-		s.channelID,
+		channelID,
 		slack.MsgOptionText(msg, false),
 	)
 	if err != nil {
-		s.logger.Errorf("Failed to send notification to the channel %s, error: %v", s.channelID, err)
+		s.logger.Errorf("Failed to send notification to the channel %s, error: %v", channelID, err)
 		return err
 	}
 
@@ -62,7 +67,7 @@ func (s *Slack) Notify(msg string) error {
 	}
 	localTime := time.UnixMilli(timeInMS * int64(time.Microsecond)).Local()
 
-	s.logger.Infof("Message successfully sent to the channel %s at %s", s.channelID, localTime.String())
+	s.logger.Infof("Message successfully sent to the channel %s at %s", channelID, localTime.String())
 	return nil
 }
 
@@ -43,6 +43,15 @@ func (n *Notification) Notify(msg string) error {
 	return combinedError
 }
 
+// NotifySlackChannel sends a message to the given slack channel, or to the configured channels when the slack channel is empty.
+func (n *Notification) NotifySlackChannel(slackChannelID string, msg string) error {
+	if len(slackChannelID) == 0 || n.slack == nil {
+		return n.Notify(msg)
+	}
+
+	return n.slack.NotifyChannel(slackChannelID, msg)
+}
+
 func GetNotification(ctx context.Context, logger log.Logger, client slack.SlackClient, notificationConfig *config.Notification) *Notification {
 	return &Notification{
 		slack: slack.GetSlackClient(ctx, logger, client, notificationConfig.Slack),